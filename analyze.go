@@ -0,0 +1,302 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Iframe sandbox permissions reported by [Analyze].
+const (
+	SandboxAllowScripts    = "allow-scripts"
+	SandboxAllowForms      = "allow-forms"
+	SandboxAllowPopups     = "allow-popups"
+	SandboxAllowModals     = "allow-modals"
+	SandboxAllowSameOrigin = "allow-same-origin"
+)
+
+// Report describes what a piece of UI content does, as determined by
+// static inspection. It is produced by [Analyze].
+//
+// The analysis is heuristic: it finds constructs written literally in the
+// markup and scripts, but cannot see URLs or code assembled at runtime.
+// Treat it as an inventory for review and CI gates, not as a guarantee.
+type Report struct {
+	// MIMEType is the MIME type of the analyzed content.
+	MIMEType string `json:"mimeType"`
+	// InlineScripts lists the inline scripts found in HTML content.
+	InlineScripts []InlineScript `json:"inlineScripts,omitempty"`
+	// InlineEventHandlers counts on* attributes such as onclick.
+	InlineEventHandlers int `json:"inlineEventHandlers,omitempty"`
+	// ScriptOrigins lists origins of external scripts.
+	ScriptOrigins []string `json:"scriptOrigins,omitempty"`
+	// StyleOrigins lists origins of external stylesheets and @imports.
+	StyleOrigins []string `json:"styleOrigins,omitempty"`
+	// ImageOrigins lists origins of images and CSS url() references.
+	ImageOrigins []string `json:"imageOrigins,omitempty"`
+	// ConnectOrigins lists origins contacted by fetch, XMLHttpRequest,
+	// WebSocket and EventSource calls in scripts.
+	ConnectOrigins []string `json:"connectOrigins,omitempty"`
+	// FrameOrigins lists origins loaded in frames, including the URLs of
+	// [URLContent].
+	FrameOrigins []string `json:"frameOrigins,omitempty"`
+	// FormTargets lists form action and formaction values. An empty string
+	// means the form submits to the document itself.
+	FormTargets []string `json:"formTargets,omitempty"`
+	// UsesPostMessage reports whether any script calls postMessage.
+	UsesPostMessage bool `json:"usesPostMessage,omitempty"`
+	// EvalUsages lists eval-like constructs found in scripts.
+	EvalUsages []string `json:"evalUsages,omitempty"`
+	// SandboxPermissions lists the iframe sandbox permissions the content
+	// appears to need.
+	SandboxPermissions []string `json:"sandboxPermissions,omitempty"`
+	// Notes contains observations that do not fit the fields above.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// InlineScript describes an inline <script> element.
+type InlineScript struct {
+	// Hash is the CSP source expression for the script, see [CSPHash].
+	Hash string `json:"hash"`
+	// Size is the length of the script in bytes.
+	Size int `json:"size"`
+}
+
+// NeedsPermission reports whether the content appears to need the given
+// sandbox permission.
+func (r *Report) NeedsPermission(permission string) bool {
+	return slices.Contains(r.SandboxPermissions, permission)
+}
+
+var (
+	postMessagePattern = regexp.MustCompile(`\bpostMessage\s*\(`)
+	evalPatterns       = []struct {
+		name    string
+		pattern *regexp.Regexp
+	}{
+		{"eval", regexp.MustCompile(`\beval\s*\(`)},
+		{"new Function", regexp.MustCompile(`\bnew\s+Function\s*\(`)},
+		{"setTimeout(string)", regexp.MustCompile("\\bsetTimeout\\s*\\(\\s*['\"`]")},
+		{"setInterval(string)", regexp.MustCompile("\\bsetInterval\\s*\\(\\s*['\"`]")},
+	}
+	connectPatterns = []*regexp.Regexp{
+		regexp.MustCompile("\\bfetch\\s*\\(\\s*['\"`]([^'\"`]+)"),
+		regexp.MustCompile("\\bnew\\s+(?:WebSocket|EventSource)\\s*\\(\\s*['\"`]([^'\"`]+)"),
+		regexp.MustCompile("\\.open\\s*\\(\\s*['\"`][A-Za-z]+['\"`]\\s*,\\s*['\"`]([^'\"`]+)"),
+	}
+	popupPattern      = regexp.MustCompile(`\bwindow\.open\s*\(`)
+	modalPattern      = regexp.MustCompile(`\b(?:alert|confirm|prompt)\s*\(`)
+	sameOriginPattern = regexp.MustCompile(`\b(?:localStorage|sessionStorage|indexedDB|document\.cookie)\b`)
+	cssURLPattern     = regexp.MustCompile(`url\(\s*['"]?([^'")\s]+)`)
+	cssImportPattern  = regexp.MustCompile(`@import\s+(?:url\(\s*)?['"]?([^'")\s;]+)`)
+)
+
+// Analyze inspects UI content and reports the scripts, external origins,
+// forms and sandbox permissions it uses.
+//
+// Example:
+//
+//	report := mcpui.Analyze(content)
+//	if len(report.EvalUsages) > 0 {
+//		log.Printf("resource uses eval: %v", report.EvalUsages)
+//	}
+func Analyze(content UIContent) Report {
+	a := &analyzer{}
+	if content == nil {
+		a.report.Notes = append(a.report.Notes, "no content")
+		return a.report
+	}
+	a.report.MIMEType = content.mimeType()
+
+	switch c := content.(type) {
+	case *HTMLContent:
+		a.analyzeHTML(c.HTML)
+	case *MCPAppContent:
+		a.analyzeHTML(c.HTML)
+	case *SkybridgeContent:
+		a.analyzeHTML(c.HTML)
+	case *URLContent:
+		for _, u := range c.URLs() {
+			a.addOrigin(&a.report.FrameOrigins, u)
+		}
+		a.report.Notes = append(a.report.Notes, "external page content is not analyzed")
+	case *RemoteDOMContent:
+		a.needs(SandboxAllowScripts)
+		a.analyzeScript(c.Script)
+	default:
+		a.report.Notes = append(a.report.Notes, "binary content is not analyzed")
+	}
+
+	a.finish()
+	return a.report
+}
+
+type analyzer struct {
+	report Report
+}
+
+func (a *analyzer) add(list *[]string, value string) {
+	if !slices.Contains(*list, value) {
+		*list = append(*list, value)
+	}
+}
+
+func (a *analyzer) needs(permission string) {
+	a.add(&a.report.SandboxPermissions, permission)
+}
+
+func (a *analyzer) addOrigin(list *[]string, rawURL string) {
+	if origin := originOf(rawURL); origin != "" {
+		a.add(list, origin)
+	}
+}
+
+func (a *analyzer) finish() {
+	for _, list := range []*[]string{
+		&a.report.ScriptOrigins, &a.report.StyleOrigins, &a.report.ImageOrigins,
+		&a.report.ConnectOrigins, &a.report.FrameOrigins, &a.report.SandboxPermissions,
+	} {
+		slices.Sort(*list)
+	}
+}
+
+func (a *analyzer) analyzeHTML(src string) {
+	tokens := tokenizeHTML(src)
+	for i := range tokens {
+		tok := &tokens[i]
+		if tok.Type != htmlStartTag && tok.Type != htmlSelfClosingTag {
+			continue
+		}
+		for _, attr := range tok.Attrs {
+			if strings.HasPrefix(attr.Key, "on") {
+				a.report.InlineEventHandlers++
+				a.needs(SandboxAllowScripts)
+				a.analyzeScript(attr.Val)
+			}
+			if attr.Key == "href" || attr.Key == "src" {
+				if strings.HasPrefix(strings.ToLower(strings.TrimSpace(attr.Val)), "javascript:") {
+					a.needs(SandboxAllowScripts)
+					a.analyzeScript(attr.Val)
+				}
+			}
+			if attr.Key == "formaction" {
+				a.add(&a.report.FormTargets, attr.Val)
+				a.needs(SandboxAllowForms)
+			}
+			if attr.Key == "style" {
+				a.analyzeCSS(attr.Val)
+			}
+		}
+
+		switch tok.Data {
+		case "script":
+			if !isJavaScriptType(tok) {
+				continue
+			}
+			a.needs(SandboxAllowScripts)
+			if src, ok := tok.attr("src"); ok {
+				a.addOrigin(&a.report.ScriptOrigins, src)
+				continue
+			}
+			body := inlineBody(tokens, i)
+			a.report.InlineScripts = append(a.report.InlineScripts, InlineScript{Hash: CSPHash(body), Size: len(body)})
+			a.analyzeScript(body)
+		case "style":
+			a.analyzeCSS(inlineBody(tokens, i))
+		case "link":
+			rel, _ := tok.attr("rel")
+			href, _ := tok.attr("href")
+			if strings.Contains(strings.ToLower(rel), "stylesheet") {
+				a.addOrigin(&a.report.StyleOrigins, href)
+			}
+		case "img":
+			src, _ := tok.attr("src")
+			a.addOrigin(&a.report.ImageOrigins, src)
+		case "iframe", "frame":
+			src, _ := tok.attr("src")
+			a.addOrigin(&a.report.FrameOrigins, src)
+		case "form":
+			action, _ := tok.attr("action")
+			a.add(&a.report.FormTargets, action)
+			a.needs(SandboxAllowForms)
+		case "a":
+			if target, _ := tok.attr("target"); target == "_blank" {
+				a.needs(SandboxAllowPopups)
+			}
+		}
+	}
+}
+
+func (a *analyzer) analyzeScript(script string) {
+	if postMessagePattern.MatchString(script) {
+		a.report.UsesPostMessage = true
+	}
+	for _, p := range evalPatterns {
+		if p.pattern.MatchString(script) {
+			a.add(&a.report.EvalUsages, p.name)
+		}
+	}
+	for _, p := range connectPatterns {
+		for _, m := range p.FindAllStringSubmatch(script, -1) {
+			a.addOrigin(&a.report.ConnectOrigins, m[1])
+		}
+	}
+	if popupPattern.MatchString(script) {
+		a.needs(SandboxAllowPopups)
+	}
+	if modalPattern.MatchString(script) {
+		a.needs(SandboxAllowModals)
+	}
+	if sameOriginPattern.MatchString(script) {
+		a.needs(SandboxAllowSameOrigin)
+	}
+}
+
+func (a *analyzer) analyzeCSS(css string) {
+	imports := map[string]bool{}
+	for _, m := range cssImportPattern.FindAllStringSubmatch(css, -1) {
+		imports[m[1]] = true
+		a.addOrigin(&a.report.StyleOrigins, m[1])
+	}
+	for _, m := range cssURLPattern.FindAllStringSubmatch(css, -1) {
+		if !imports[m[1]] {
+			a.addOrigin(&a.report.ImageOrigins, m[1])
+		}
+	}
+}
+
+// isJavaScriptType reports whether a script element will be executed as
+// JavaScript based on its type attribute.
+func isJavaScriptType(tok *htmlToken) bool {
+	typ, ok := tok.attr("type")
+	if !ok {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(typ)) {
+	case "", "module", "text/javascript", "application/javascript", "text/ecmascript", "application/ecmascript":
+		return true
+	}
+	return false
+}
+
+// originOf returns the origin (scheme://host) of an absolute URL, "data:"
+// for data URLs, and "" for relative or unparseable URLs.
+func originOf(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if strings.HasPrefix(strings.ToLower(rawURL), "data:") {
+		return "data:"
+	}
+	if strings.HasPrefix(rawURL, "//") {
+		rawURL = "https:" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host)
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_HTML(t *testing.T) {
+	content := &HTMLContent{HTML: `<!DOCTYPE html>
+<html>
+<head>
+  <link rel="stylesheet" href="https://cdn.example.com/app.css">
+  <script src="https://cdn.example.com/lib.js"></script>
+  <style>@import url("https://fonts.example.com/font.css"); body { background: url(https://img.example.com/bg.png); }</style>
+  <script type="application/json">{"not": "executed"}</script>
+</head>
+<body>
+  <img src="https://img.example.com/logo.png">
+  <img src="local.png">
+  <form action="https://api.example.com/submit"><button onclick="track()">Go</button></form>
+  <a href="https://example.com" target="_blank">out</a>
+  <script>
+    fetch("https://api.example.com/data").then(r => r.json());
+    window.parent.postMessage({type: "tool"}, "*");
+    setTimeout("tick()", 10);
+    localStorage.setItem("k", "v");
+  </script>
+</body>
+</html>`}
+
+	r := Analyze(content)
+	assert.Equal(t, MIMETypeHTML, r.MIMEType)
+	require.Len(t, r.InlineScripts, 1)
+	assert.Contains(t, r.InlineScripts[0].Hash, "'sha256-")
+	assert.Equal(t, 1, r.InlineEventHandlers)
+	assert.Equal(t, []string{"https://cdn.example.com"}, r.ScriptOrigins)
+	assert.Equal(t, []string{"https://cdn.example.com", "https://fonts.example.com"}, r.StyleOrigins)
+	assert.Equal(t, []string{"https://img.example.com"}, r.ImageOrigins)
+	assert.Equal(t, []string{"https://api.example.com"}, r.ConnectOrigins)
+	assert.Equal(t, []string{"https://api.example.com/submit"}, r.FormTargets)
+	assert.True(t, r.UsesPostMessage)
+	assert.Equal(t, []string{"setTimeout(string)"}, r.EvalUsages)
+	assert.Equal(t, []string{
+		SandboxAllowForms, SandboxAllowPopups, SandboxAllowSameOrigin, SandboxAllowScripts,
+	}, r.SandboxPermissions)
+	assert.True(t, r.NeedsPermission(SandboxAllowScripts))
+	assert.False(t, r.NeedsPermission(SandboxAllowModals))
+}
+
+func TestAnalyze_StaticHTML(t *testing.T) {
+	r := Analyze(&HTMLContent{HTML: `<p>Hello</p><script type="application/ld+json">{}</script>`})
+	assert.Empty(t, r.InlineScripts)
+	assert.Empty(t, r.SandboxPermissions)
+	assert.False(t, r.UsesPostMessage)
+}
+
+func TestAnalyze_MCPApp(t *testing.T) {
+	r := Analyze(&MCPAppContent{HTML: `<script>eval(code); fetch("https://evil.example/x");</script>`})
+	assert.Equal(t, MIMETypeMCPApp, r.MIMEType)
+	require.Len(t, r.InlineScripts, 1)
+	assert.Equal(t, []string{"eval"}, r.EvalUsages)
+	assert.Equal(t, []string{"https://evil.example"}, r.ConnectOrigins)
+	assert.Equal(t, []string{SandboxAllowScripts}, r.SandboxPermissions)
+	assert.Empty(t, r.Notes)
+}
+
+func TestAnalyze_Skybridge(t *testing.T) {
+	r := Analyze(&SkybridgeContent{HTML: `<script>eval(code); fetch("https://evil.example/x");</script>`})
+	assert.Equal(t, MIMETypeSkybridge, r.MIMEType)
+	require.Len(t, r.InlineScripts, 1)
+	assert.Equal(t, []string{"eval"}, r.EvalUsages)
+	assert.Equal(t, []string{"https://evil.example"}, r.ConnectOrigins)
+	assert.Empty(t, r.Notes)
+
+	// Adapted widgets are analyzed with the injected shim.
+	widget, err := NewOpenAIAdapter(NewRouter()).Adapt("ui://x", &HTMLContent{HTML: "<p>static</p>"})
+	require.NoError(t, err)
+	r = Analyze(widget)
+	assert.Len(t, r.InlineScripts, 1)
+	assert.Empty(t, r.EvalUsages)
+}
+
+func TestAnalyze_RemoteDOM(t *testing.T) {
+	r := Analyze(&RemoteDOMContent{
+		Script:    `const f = new Function("return 1"); eval(code); new WebSocket("wss://live.example.com/ws"); alert("hi");`,
+		Framework: FrameworkReact,
+	})
+	assert.Equal(t, "application/vnd.mcp-ui.remote-dom+javascript; framework=react", r.MIMEType)
+	assert.Equal(t, []string{"eval", "new Function"}, r.EvalUsages)
+	assert.Equal(t, []string{"wss://live.example.com"}, r.ConnectOrigins)
+	assert.Equal(t, []string{SandboxAllowModals, SandboxAllowScripts}, r.SandboxPermissions)
+}
+
+func TestAnalyze_URL(t *testing.T) {
+	r := Analyze(&URLContent{URL: "https://Dashboard.Example.com/app?x=1", Fallbacks: []string{"https://backup.example.com/app"}})
+	assert.Equal(t, []string{"https://backup.example.com", "https://dashboard.example.com"}, r.FrameOrigins)
+	assert.NotEmpty(t, r.Notes)
+}
+
+func TestAnalyze_Blob(t *testing.T) {
+	r := Analyze(&BlobContent{Data: []byte{1}, ContentMIMEType: "image/png"})
+	assert.Equal(t, "image/png", r.MIMEType)
+	assert.NotEmpty(t, r.Notes)
+}
+
+func TestOriginOf(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://example.com/a/b", "https://example.com"},
+		{"//cdn.example.com/x.js", "https://cdn.example.com"},
+		{"data:image/png;base64,AAAA", "data:"},
+		{"/relative/path", ""},
+		{"img.png", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, originOf(tt.in))
+		})
+	}
+}
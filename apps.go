@@ -0,0 +1,180 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MCP Apps profile identifiers.
+const (
+	// ProfileMCPApp is the profile parameter value of MCP Apps HTML.
+	ProfileMCPApp = "mcp-app"
+	// MIMETypeMCPApp is the MIME type of MCP Apps resources, exactly as
+	// MCP Apps hosts expect it.
+	MIMETypeMCPApp = MIMETypeHTML + ";" + MIMEParamProfile + "=" + ProfileMCPApp
+	// MCPAppsExtension is the capability extension key under which MCP Apps
+	// hosts advertise support.
+	MCPAppsExtension = "io.modelcontextprotocol/ui"
+)
+
+// MetaKeyUI is the _meta key holding MCP Apps metadata on resources and
+// tools.
+const MetaKeyUI = "ui"
+
+// MetaKeyLegacyResourceURI is the flat _meta key used by early MCP Apps
+// hosts to link a tool to its UI resource.
+const MetaKeyLegacyResourceURI = "ui/resourceUri"
+
+// Tool visibility values for [ToolUIMeta].Visibility.
+const (
+	// VisibilityModel makes the tool available to the model.
+	VisibilityModel = "model"
+	// VisibilityApp makes the tool callable from the app's UI.
+	VisibilityApp = "app"
+)
+
+// MCPAppContent is HTML for hosts implementing the MCP Apps extension.
+// Its MIME type is "text/html;profile=mcp-app". Unlike [HTMLContent], the
+// page talks to the host with JSON-RPC over postMessage rather than
+// MCP-UI actions, and its resource carries [MCPAppResourceMeta] under
+// _meta.ui.
+//
+// Example:
+//
+//	content := &mcpui.MCPAppContent{
+//		HTML: page,
+//		UI: &mcpui.MCPAppResourceMeta{
+//			CSP: &mcpui.MCPAppCSP{ConnectDomains: []string{"https://api.example.com"}},
+//		},
+//	}
+type MCPAppContent struct {
+	// HTML is the document to render.
+	HTML string
+	// UI holds the resource metadata, if any.
+	UI *MCPAppResourceMeta
+	// Annotations contains optional metadata.
+	Annotations *Annotations
+}
+
+// MCPAppResourceMeta is the _meta.ui metadata of an MCP Apps resource.
+type MCPAppResourceMeta struct {
+	// CSP lists the external origins the app may use.
+	CSP *MCPAppCSP `json:"csp,omitempty"`
+	// Domain requests a dedicated origin for the app's sandbox.
+	Domain string `json:"domain,omitempty"`
+	// PrefersBorder asks the host to draw a border around the app.
+	PrefersBorder *bool `json:"prefersBorder,omitempty"`
+}
+
+// MCPAppCSP lists the origins an MCP Apps host adds to the app's Content
+// Security Policy.
+type MCPAppCSP struct {
+	// ConnectDomains are origins for fetch, XHR and WebSocket connections.
+	ConnectDomains []string `json:"connectDomains,omitempty"`
+	// ResourceDomains are origins for scripts, styles, images and fonts.
+	ResourceDomains []string `json:"resourceDomains,omitempty"`
+}
+
+// MarshalJSON serializes MCPAppContent to the wire format.
+func (c *MCPAppContent) MarshalJSON() ([]byte, error) {
+	wire := &wireUIContent{
+		MIMEType:    c.mimeType(),
+		Text:        c.HTML,
+		Annotations: c.Annotations,
+	}
+	if c.UI != nil {
+		wire.Meta = map[string]any{MetaKeyUI: c.UI}
+	}
+	return json.Marshal(wire)
+}
+
+func (c *MCPAppContent) mimeType() string { return MIMETypeMCPApp }
+
+func (c *MCPAppContent) fromWire(wire *wireUIContent) error {
+	text, _, err := wire.text()
+	if err != nil {
+		return err
+	}
+	c.HTML = text
+	c.Annotations = wire.Annotations
+	c.UI = nil
+	if raw, ok := wire.Meta[MetaKeyUI]; ok {
+		var ui MCPAppResourceMeta
+		if err := remarshal(raw, &ui); err != nil {
+			return fmt.Errorf("invalid _meta.%s: %w", MetaKeyUI, err)
+		}
+		c.UI = &ui
+	}
+	return nil
+}
+
+// ToolUIMeta is the _meta.ui metadata of a tool definition, linking the
+// tool to the MCP Apps resource that renders its results.
+type ToolUIMeta struct {
+	// ResourceURI is the ui:// URI of the resource to render.
+	ResourceURI string `json:"resourceUri"`
+	// Visibility lists who may call the tool: VisibilityModel,
+	// VisibilityApp or both. Empty means both.
+	Visibility []string `json:"visibility,omitempty"`
+}
+
+// Meta returns a tool _meta object carrying m under "ui", plus the legacy
+// "ui/resourceUri" key for older hosts. Merge it into the tool
+// definition's _meta with [SetToolUIMeta] if other entries are present.
+func (m *ToolUIMeta) Meta() map[string]any {
+	return SetToolUIMeta(nil, m)
+}
+
+// SetToolUIMeta stores m in a tool's _meta, creating the map if needed, and
+// returns it. If m is nil, meta is returned unchanged.
+//
+// Example:
+//
+//	tool.Meta = mcpui.SetToolUIMeta(tool.Meta, &mcpui.ToolUIMeta{ResourceURI: "ui://weather/forecast"})
+func SetToolUIMeta(meta map[string]any, m *ToolUIMeta) map[string]any {
+	if m == nil {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[MetaKeyUI] = m
+	meta[MetaKeyLegacyResourceURI] = m.ResourceURI
+	return meta
+}
+
+// ParseToolUIMeta reads the MCP Apps metadata from a tool's _meta, as
+// decoded from JSON or built with [SetToolUIMeta]. It falls back to the
+// legacy "ui/resourceUri" key and reports false if neither is present.
+func ParseToolUIMeta(meta map[string]any) (*ToolUIMeta, bool) {
+	m, _, ok := parseToolUIMeta(meta)
+	return m, ok
+}
+
+// parseToolUIMeta is [ParseToolUIMeta], also returning the key the resource
+// URI was found under.
+func parseToolUIMeta(meta map[string]any) (*ToolUIMeta, string, bool) {
+	if raw, ok := meta[MetaKeyUI]; ok {
+		var m ToolUIMeta
+		if err := remarshal(raw, &m); err == nil && m.ResourceURI != "" {
+			return &m, MetaKeyUI, true
+		}
+	}
+	if uri, ok := meta[MetaKeyLegacyResourceURI].(string); ok && uri != "" {
+		return &ToolUIMeta{ResourceURI: uri}, MetaKeyLegacyResourceURI, true
+	}
+	return nil, "", false
+}
+
+// remarshal converts a decoded JSON value, or a Go value, to v.
+func remarshal(src, v any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
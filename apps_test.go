@@ -0,0 +1,144 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPAppContent_Wire(t *testing.T) {
+	border := true
+	content := &MCPAppContent{
+		HTML: "<p>app</p>",
+		UI: &MCPAppResourceMeta{
+			CSP:           &MCPAppCSP{ConnectDomains: []string{"https://api.example.com"}},
+			PrefersBorder: &border,
+		},
+	}
+	data, err := json.Marshal(content)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"mimeType": "text/html;profile=mcp-app",
+		"text": "<p>app</p>",
+		"_meta": {"ui": {"csp": {"connectDomains": ["https://api.example.com"]}, "prefersBorder": true}}
+	}`, string(data))
+
+	rc, err := NewUIResourceContents("ui://app", content)
+	require.NoError(t, err)
+	assert.Equal(t, "text/html;profile=mcp-app", rc.MIMEType, "the MIME type is emitted without a space")
+	data, err = json.Marshal(rc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"uri": "ui://app",
+		"mimeType": "text/html;profile=mcp-app",
+		"text": "<p>app</p>",
+		"_meta": {"ui": {"csp": {"connectDomains": ["https://api.example.com"]}, "prefersBorder": true}}
+	}`, string(data))
+
+	// Round trip through the JSON wire format, as a client would see it.
+	var decoded UIResourceContents
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	back, err := decoded.ToUIContent()
+	require.NoError(t, err)
+	assert.Equal(t, content, back)
+
+	assert.Equal(t, "app", TextFallback(content))
+}
+
+func TestContentFromWire_MCPApp(t *testing.T) {
+	tests := []struct {
+		name     string
+		mimeType string
+		wantApp  bool
+	}{
+		{"exact", "text/html;profile=mcp-app", true},
+		{"spaced and mixed case", "Text/HTML; Profile=MCP-App", true},
+		{"other profile", "text/html;profile=custom", false},
+		{"plain HTML", "text/html", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := ContentFromWire(&wireUIContent{MIMEType: tt.mimeType, Text: "<p>x</p>"})
+			require.NoError(t, err)
+			_, isApp := content.(*MCPAppContent)
+			assert.Equal(t, tt.wantApp, isApp)
+		})
+	}
+
+	_, err := ContentFromWire(&wireUIContent{MIMEType: MIMETypeMCPApp, Text: "x", Meta: map[string]any{"ui": "not an object"}})
+	assert.Error(t, err)
+}
+
+func TestToolUIMeta(t *testing.T) {
+	m := &ToolUIMeta{ResourceURI: "ui://weather/forecast", Visibility: []string{VisibilityModel, VisibilityApp}}
+	data, err := json.Marshal(m.Meta())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ui": {"resourceUri": "ui://weather/forecast", "visibility": ["model", "app"]},
+		"ui/resourceUri": "ui://weather/forecast"
+	}`, string(data))
+
+	meta := SetToolUIMeta(map[string]any{"other": 1}, &ToolUIMeta{ResourceURI: "ui://a"})
+	assert.Equal(t, 1, meta["other"])
+
+	// A nil ToolUIMeta leaves _meta unchanged.
+	assert.Equal(t, map[string]any{"other": 1}, SetToolUIMeta(map[string]any{"other": 1}, nil))
+	assert.Nil(t, SetToolUIMeta(nil, nil))
+	assert.Nil(t, (*ToolUIMeta)(nil).Meta())
+
+	// Built in Go.
+	got, ok := ParseToolUIMeta(meta)
+	require.True(t, ok)
+	assert.Equal(t, &ToolUIMeta{ResourceURI: "ui://a"}, got)
+
+	// Decoded from JSON.
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	got, ok = ParseToolUIMeta(decoded)
+	require.True(t, ok)
+	assert.Equal(t, m, got)
+
+	got, ok = ParseToolUIMeta(map[string]any{"ui/resourceUri": "ui://legacy"})
+	require.True(t, ok)
+	assert.Equal(t, "ui://legacy", got.ResourceURI)
+
+	_, ok = ParseToolUIMeta(map[string]any{"ui": map[string]any{"visibility": []any{"app"}}})
+	assert.False(t, ok)
+	_, ok = ParseToolUIMeta(nil)
+	assert.False(t, ok)
+}
+
+func TestNegotiate_MCPApp(t *testing.T) {
+	app := &MCPAppContent{HTML: "<p>app</p>"}
+	html := &HTMLContent{HTML: "<p>html</p>"}
+
+	caps, err := ParseClientCapabilities(json.RawMessage(`{
+		"capabilities": {"extensions": {"io.modelcontextprotocol/ui": {"mimeTypes": ["text/html;profile=mcp-app"]}}}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{MIMETypeMCPApp}, caps.MIMETypes)
+	assert.True(t, caps.Supports(app))
+	assert.False(t, caps.Supports(html))
+
+	// An MCP-UI host accepting text/html does not get MCP Apps content.
+	mcpUI := &ClientCapabilities{MIMETypes: []string{"text/html"}}
+	content, ok := Negotiate(mcpUI, app, html)
+	require.True(t, ok)
+	assert.Same(t, html, content)
+
+	content, ok = Negotiate(caps, app, html)
+	require.True(t, ok)
+	assert.Same(t, app, content)
+}
+
+func TestUIResourceContents_HashIncludesMeta(t *testing.T) {
+	a := &UIResourceContents{URI: "ui://a", MIMEType: MIMETypeMCPApp, Text: "x"}
+	b := &UIResourceContents{URI: "ui://a", MIMEType: MIMETypeMCPApp, Text: "x", Meta: map[string]any{"ui": map[string]any{"domain": "a.example"}}}
+	assert.NotEqual(t, a.Hash(), b.Hash())
+}
@@ -0,0 +1,518 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Default size limits for [Bundler].
+const (
+	// DefaultMaxAssetSize is the default limit for a single inlined asset.
+	DefaultMaxAssetSize = 2 << 20 // 2 MiB
+	// DefaultMaxBundleSize is the default limit for the bundled HTML.
+	DefaultMaxBundleSize = 8 << 20 // 8 MiB
+)
+
+// ErrBundleTooLarge is returned when an asset or the bundled output exceeds
+// the configured size limits.
+var ErrBundleTooLarge = errors.New("bundle size limit exceeded")
+
+// Bundler turns an HTML entry file and its local assets into a single
+// self-contained [HTMLContent].
+//
+// Because HTMLContent is rendered via iframe srcdoc, relative URLs cannot be
+// resolved by the client. The bundler inlines everything it can find in the
+// file system:
+//
+//   - <link rel="stylesheet"> becomes a <style> element
+//   - <script src> becomes an inline <script>
+//   - <img src>, <source src>, <video poster> and <link rel="icon"> become data URIs
+//   - CSS @import rules are inlined and CSS url() references (images and
+//     fonts) become data URIs, in stylesheets, <style> elements and style
+//     attributes
+//
+// Absolute URLs (https:, //host, data:) are left untouched.
+//
+// Example:
+//
+//	//go:embed ui
+//	var uiFS embed.FS
+//
+//	content, err := mcpui.NewBundler(uiFS).Bundle("ui/index.html")
+type Bundler struct {
+	// FS is the file system assets are read from.
+	FS fs.FS
+	// MaxAssetSize limits the size of each inlined file. Zero means no limit.
+	MaxAssetSize int64
+	// MaxBundleSize limits the size of the bundled HTML. Zero means no limit.
+	MaxBundleSize int64
+	// Minify removes comments and collapses insignificant whitespace in
+	// HTML and CSS. Scripts are never rewritten.
+	Minify bool
+}
+
+// NewBundler creates a Bundler reading from fsys with the default size limits.
+func NewBundler(fsys fs.FS) *Bundler {
+	return &Bundler{
+		FS:            fsys,
+		MaxAssetSize:  DefaultMaxAssetSize,
+		MaxBundleSize: DefaultMaxBundleSize,
+	}
+}
+
+// Bundle reads the entry HTML file and returns it with its assets inlined.
+func (b *Bundler) Bundle(entry string) (*HTMLContent, error) {
+	if b.FS == nil {
+		return nil, errors.New("bundler has no file system")
+	}
+	src, err := b.read(entry)
+	if err != nil {
+		return nil, err
+	}
+	out, err := b.bundleHTML(entry, string(src))
+	if err != nil {
+		return nil, err
+	}
+	if b.MaxBundleSize > 0 && int64(len(out)) > b.MaxBundleSize {
+		return nil, fmt.Errorf("%w: bundle is %d bytes, limit %d", ErrBundleTooLarge, len(out), b.MaxBundleSize)
+	}
+	return &HTMLContent{HTML: out}, nil
+}
+
+// assetMIMETypes covers extensions that mime.TypeByExtension may not know.
+var assetMIMETypes = map[string]string{
+	".css":   "text/css",
+	".js":    "text/javascript",
+	".mjs":   "text/javascript",
+	".svg":   "image/svg+xml",
+	".png":   "image/png",
+	".jpg":   "image/jpeg",
+	".jpeg":  "image/jpeg",
+	".gif":   "image/gif",
+	".webp":  "image/webp",
+	".avif":  "image/avif",
+	".ico":   "image/x-icon",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".ttf":   "font/ttf",
+	".otf":   "font/otf",
+	".eot":   "application/vnd.ms-fontobject",
+	".mp4":   "video/mp4",
+	".webm":  "video/webm",
+	".mp3":   "audio/mpeg",
+	".wav":   "audio/wav",
+	".json":  "application/json",
+}
+
+// mimeTypeForPath returns the MIME type for a file name based on its extension.
+func mimeTypeForPath(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	if t, ok := assetMIMETypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+var (
+	cssURLRefPattern = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^)"'\s]*))\s*\)`)
+	cssImportRule    = regexp.MustCompile(`@import\s+(?:url\(\s*)?(?:"([^"]*)"|'([^']*)'|([^)"'\s;]+))\s*\)?\s*([^;]*);`)
+)
+
+func (b *Bundler) read(name string) ([]byte, error) {
+	info, err := fs.Stat(b.FS, name)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %w", err)
+	}
+	if b.MaxAssetSize > 0 && info.Size() > b.MaxAssetSize {
+		return nil, fmt.Errorf("%w: %s is %d bytes, limit %d", ErrBundleTooLarge, name, info.Size(), b.MaxAssetSize)
+	}
+	data, err := fs.ReadFile(b.FS, name)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %w", err)
+	}
+	return data, nil
+}
+
+// resolveAsset maps a reference found in base to a file system path. It
+// returns false for absolute URLs, data URLs and fragment-only references.
+func resolveAsset(base, ref string) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "//") {
+		return "", false
+	}
+	if i := strings.IndexAny(ref, "?#"); i != -1 {
+		ref = ref[:i]
+	}
+	if colon := strings.IndexByte(ref, ':'); colon != -1 && !strings.Contains(ref[:colon], "/") {
+		return "", false // has a scheme
+	}
+	if strings.HasPrefix(ref, "/") {
+		return path.Clean(strings.TrimPrefix(ref, "/")), true
+	}
+	return path.Join(path.Dir(base), ref), true
+}
+
+// dataURI reads a local asset and returns it as a data URI.
+func (b *Bundler) dataURI(base, ref string) (string, bool, error) {
+	name, ok := resolveAsset(base, ref)
+	if !ok {
+		return "", false, nil
+	}
+	data, err := b.read(name)
+	if err != nil {
+		return "", false, err
+	}
+	return "data:" + mimeTypeForPath(name) + ";base64," + base64.StdEncoding.EncodeToString(data), true, nil
+}
+
+func (b *Bundler) bundleHTML(entry, src string) (string, error) {
+	tokens := tokenizeHTML(src)
+	var out strings.Builder
+	inPre := 0
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok.Type {
+		case htmlComment:
+			if !b.Minify {
+				out.WriteString(tok.Raw)
+			}
+			continue
+		case htmlText:
+			// The content of raw text elements such as <script> and
+			// <textarea> is significant, newlines included.
+			rawText := i > 0 && tokens[i-1].Type == htmlStartTag && rawTextElements[tokens[i-1].Data]
+			if b.Minify && inPre == 0 && !rawText {
+				out.WriteString(collapseSpace(tok.Raw))
+			} else {
+				out.WriteString(tok.Raw)
+			}
+			continue
+		case htmlEndTag:
+			if tok.Data == "pre" && inPre > 0 {
+				inPre--
+			}
+			out.WriteString(tok.String())
+			continue
+		case htmlStartTag, htmlSelfClosingTag:
+		default:
+			out.WriteString(tok.Raw)
+			continue
+		}
+
+		if style, ok := tok.attr("style"); ok {
+			css, err := b.bundleCSS(entry, style, 0)
+			if err != nil {
+				return "", err
+			}
+			tok.setAttr("style", css)
+		}
+
+		switch tok.Data {
+		case "pre":
+			if tok.Type == htmlStartTag {
+				inPre++
+			}
+		case "link":
+			rel, _ := tok.attr("rel")
+			href, _ := tok.attr("href")
+			rels := strings.Fields(strings.ToLower(rel))
+			if slices.Contains(rels, "stylesheet") {
+				name, ok := resolveAsset(entry, href)
+				if !ok {
+					break
+				}
+				data, err := b.read(name)
+				if err != nil {
+					return "", err
+				}
+				css, err := b.bundleCSS(name, string(data), 0)
+				if err != nil {
+					return "", err
+				}
+				style := &htmlToken{Type: htmlStartTag, Data: "style"}
+				if media, ok := tok.attr("media"); ok {
+					style.setAttr("media", media)
+				}
+				out.WriteString(style.String())
+				out.WriteString(escapeRawText(css, "style"))
+				out.WriteString("</style>")
+				continue
+			}
+			if slices.Contains(rels, "icon") || slices.Contains(rels, "apple-touch-icon") {
+				if err := b.inlineAttr(&tok, entry, "href"); err != nil {
+					return "", err
+				}
+			}
+		case "script":
+			srcAttr, ok := tok.attr("src")
+			if !ok || tok.Type != htmlStartTag {
+				break
+			}
+			name, local := resolveAsset(entry, srcAttr)
+			if !local {
+				break
+			}
+			data, err := b.read(name)
+			if err != nil {
+				return "", err
+			}
+			tok.removeAttr("src")
+			out.WriteString(tok.String())
+			out.WriteString(escapeRawText(string(data), "script"))
+			// Drop any inline content of the original element; its end
+			// tag follows and is written normally.
+			if i+1 < len(tokens) && tokens[i+1].Type == htmlText {
+				i++
+			}
+			continue
+		case "style":
+			out.WriteString(tok.String())
+			if i+1 < len(tokens) && tokens[i+1].Type == htmlText {
+				css, err := b.bundleCSS(entry, tokens[i+1].Raw, 0)
+				if err != nil {
+					return "", err
+				}
+				out.WriteString(escapeRawText(css, "style"))
+				i++
+			}
+			continue
+		case "img", "source", "audio", "track":
+			if err := b.inlineAttr(&tok, entry, "src"); err != nil {
+				return "", err
+			}
+		case "video":
+			if err := b.inlineAttr(&tok, entry, "src"); err != nil {
+				return "", err
+			}
+			if err := b.inlineAttr(&tok, entry, "poster"); err != nil {
+				return "", err
+			}
+		}
+		out.WriteString(tok.String())
+	}
+	return out.String(), nil
+}
+
+// inlineAttr replaces a local URL attribute with a data URI.
+func (b *Bundler) inlineAttr(tok *htmlToken, base, attr string) error {
+	ref, ok := tok.attr(attr)
+	if !ok {
+		return nil
+	}
+	uri, inlined, err := b.dataURI(base, ref)
+	if err != nil {
+		return err
+	}
+	if inlined {
+		tok.setAttr(attr, uri)
+	}
+	return nil
+}
+
+// maxImportDepth bounds nested @import rules and breaks import cycles.
+const maxImportDepth = 16
+
+// bundleCSS inlines local @import rules and turns local url() references
+// into data URIs. base is the path the references are relative to.
+func (b *Bundler) bundleCSS(base, css string, depth int) (string, error) {
+	if depth > maxImportDepth {
+		return "", fmt.Errorf("bundle: @import nesting too deep in %s", base)
+	}
+	var firstErr error
+	css = cssImportRule.ReplaceAllStringFunc(css, func(rule string) string {
+		m := cssImportRule.FindStringSubmatch(rule)
+		ref := m[1] + m[2] + m[3]
+		media := strings.TrimSpace(m[4])
+		name, ok := resolveAsset(base, ref)
+		if !ok || firstErr != nil {
+			return rule
+		}
+		data, err := b.read(name)
+		if err != nil {
+			firstErr = err
+			return rule
+		}
+		inlined, err := b.bundleCSS(name, string(data), depth+1)
+		if err != nil {
+			firstErr = err
+			return rule
+		}
+		if media != "" {
+			return "@media " + media + "{" + inlined + "}"
+		}
+		return inlined
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	css = cssURLRefPattern.ReplaceAllStringFunc(css, func(ref string) string {
+		m := cssURLRefPattern.FindStringSubmatch(ref)
+		uri, inlined, err := b.dataURI(base, m[1]+m[2]+m[3])
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return ref
+		}
+		if !inlined {
+			return ref
+		}
+		return `url("` + uri + `")`
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	if b.Minify {
+		css = minifyCSS(css)
+	}
+	return css, nil
+}
+
+// minifyCSS removes comments and insignificant whitespace. Whitespace is
+// dropped around {, }, ; and , and after the colon of a declaration; it is
+// kept before a colon so descendant selectors such as ".a :hover" keep
+// their meaning. Strings and url() tokens are copied verbatim.
+func minifyCSS(css string) string {
+	var out []byte
+	depth := 0
+	space := false
+	emit := func(tok string) {
+		if space && len(out) > 0 {
+			last := out[len(out)-1]
+			if !isCSSPunct(last) && !isCSSPunct(tok[0]) && !(last == ':' && depth > 0) {
+				out = append(out, ' ')
+			}
+		}
+		space = false
+		if tok == "}" && len(out) > 0 && out[len(out)-1] == ';' {
+			out = out[:len(out)-1]
+		}
+		out = append(out, tok...)
+	}
+	for i := 0; i < len(css); {
+		c := css[i]
+		switch {
+		case c == '/' && strings.HasPrefix(css[i:], "/*"):
+			end := strings.Index(css[i+2:], "*/")
+			if end < 0 {
+				i = len(css)
+			} else {
+				i += end + 4
+			}
+		case isHTMLSpace(c):
+			space = true
+			i++
+		case c == '"' || c == '\'':
+			j := cssStringEnd(css, i)
+			emit(css[i:j])
+			i = j
+		case (c == 'u' || c == 'U') && len(css) >= i+4 && strings.EqualFold(css[i:i+4], "url("):
+			j := cssURLEnd(css, i+4)
+			emit(css[i:j])
+			i = j
+		default:
+			switch c {
+			case '{':
+				depth++
+			case '}':
+				depth = max(depth-1, 0)
+			}
+			emit(css[i : i+1])
+			i++
+		}
+	}
+	return string(out)
+}
+
+func isCSSPunct(c byte) bool {
+	return c == '{' || c == '}' || c == ';' || c == ','
+}
+
+// cssStringEnd returns the index just past the string starting at i.
+func cssStringEnd(css string, i int) int {
+	quote := css[i]
+	for j := i + 1; j < len(css); j++ {
+		switch css[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1
+		}
+	}
+	return len(css)
+}
+
+// cssURLEnd returns the index just past the url( token whose contents
+// start at i.
+func cssURLEnd(css string, i int) int {
+	for j := i; j < len(css); j++ {
+		switch css[j] {
+		case '"', '\'':
+			j = cssStringEnd(css, j) - 1
+		case '\\':
+			j++
+		case ')':
+			return j + 1
+		}
+	}
+	return len(css)
+}
+
+// collapseSpace collapses runs of HTML whitespace into a single space.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(s); i++ {
+		if isHTMLSpace(s[i]) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteByte(s[i])
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// escapeRawText prevents inlined content from closing its raw text element
+// early by escaping "</name" sequences.
+func escapeRawText(s, name string) string {
+	lower := strings.ToLower(s)
+	needle := "</" + name
+	if !strings.Contains(lower, needle) {
+		return s
+	}
+	var b strings.Builder
+	for {
+		idx := strings.Index(lower, needle)
+		if idx == -1 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:idx])
+		b.WriteString(`<\/`)
+		s, lower = s[idx+2:], lower[idx+2:]
+	}
+}
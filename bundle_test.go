@@ -0,0 +1,161 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bundleFS() fstest.MapFS {
+	return fstest.MapFS{
+		"ui/index.html": {Data: []byte(`<!DOCTYPE html>
+<html>
+<head>
+  <!-- styles -->
+  <link rel="stylesheet" href="css/app.css" media="screen">
+  <link rel="stylesheet" href="https://cdn.example.com/remote.css">
+  <link rel="icon" href="/ui/img/logo.png">
+  <script src="js/app.js"></script>
+  <script src="https://cdn.example.com/lib.js"></script>
+</head>
+<body>
+  <img src="img/logo.png?v=2" alt="logo">
+  <img src="data:image/gif;base64,R0lGODlh" alt="inline">
+  <div style="background: url('img/logo.png')">x</div>
+</body>
+</html>`)},
+		"ui/css/app.css":       {Data: []byte(`@import "base.css"; body { font-family: Brand; }`)},
+		"ui/css/base.css":      {Data: []byte(`@font-face { font-family: Brand; src: url(../fonts/brand.woff2) format("woff2"); } /* base */`)},
+		"ui/fonts/brand.woff2": {Data: []byte("wOF2")},
+		"ui/js/app.js":         {Data: []byte(`document.body.innerHTML = "</script>";`)},
+		"ui/img/logo.png":      {Data: []byte{0x89, 'P', 'N', 'G'}},
+	}
+}
+
+func TestBundler_Bundle(t *testing.T) {
+	content, err := NewBundler(bundleFS()).Bundle("ui/index.html")
+	require.NoError(t, err)
+	out := content.HTML
+
+	logo := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'})
+	font := "data:font/woff2;base64," + base64.StdEncoding.EncodeToString([]byte("wOF2"))
+
+	assert.Contains(t, out, `<style media="screen">`)
+	assert.Contains(t, out, `url("`+font+`")`)
+	assert.Contains(t, out, `body { font-family: Brand; }`)
+	assert.NotContains(t, out, "@import")
+	assert.NotContains(t, out, `href="css/app.css"`)
+	assert.Contains(t, out, `href="https://cdn.example.com/remote.css"`)
+
+	assert.Contains(t, out, `<link rel="icon" href="`+logo+`">`)
+	assert.Contains(t, out, `<img src="`+logo+`" alt="logo">`)
+	assert.Contains(t, out, `src="data:image/gif;base64,R0lGODlh"`)
+	assert.Contains(t, out, `url(&#34;`+logo+`&#34;)`)
+
+	assert.Contains(t, out, `<script>document.body.innerHTML = "<\/script>";</script>`)
+	assert.Contains(t, out, `<script src="https://cdn.example.com/lib.js"></script>`)
+	assert.Contains(t, out, "<!-- styles -->")
+}
+
+func TestBundler_Minify(t *testing.T) {
+	b := NewBundler(bundleFS())
+	b.Minify = true
+	content, err := b.Bundle("ui/index.html")
+	require.NoError(t, err)
+
+	assert.NotContains(t, content.HTML, "<!-- styles -->")
+	assert.NotContains(t, content.HTML, "/* base */")
+	assert.NotContains(t, content.HTML, "\n  ")
+	assert.Contains(t, content.HTML, "body{font-family:Brand}")
+}
+
+func TestBundler_MinifyRawText(t *testing.T) {
+	fsys := fstest.MapFS{"index.html": {Data: []byte("<p>a\n  b</p><script>// hi\nrun()</script><textarea>line 1\n  line 2</textarea>")}}
+	b := NewBundler(fsys)
+	b.Minify = true
+	content, err := b.Bundle("index.html")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>a b</p><script>// hi\nrun()</script><textarea>line 1\n  line 2</textarea>", content.HTML)
+}
+
+func TestBundler_Limits(t *testing.T) {
+	b := NewBundler(bundleFS())
+	b.MaxAssetSize = 8
+	_, err := b.Bundle("ui/index.html")
+	assert.ErrorIs(t, err, ErrBundleTooLarge)
+
+	b = NewBundler(bundleFS())
+	b.MaxBundleSize = 100
+	_, err = b.Bundle("ui/index.html")
+	assert.ErrorIs(t, err, ErrBundleTooLarge)
+}
+
+func TestBundler_Errors(t *testing.T) {
+	_, err := NewBundler(bundleFS()).Bundle("ui/missing.html")
+	assert.Error(t, err)
+
+	fsys := fstest.MapFS{"index.html": {Data: []byte(`<script src="gone.js"></script>`)}}
+	_, err = NewBundler(fsys).Bundle("index.html")
+	assert.Error(t, err)
+
+	cyclic := fstest.MapFS{
+		"index.html": {Data: []byte(`<link rel="stylesheet" href="a.css">`)},
+		"a.css":      {Data: []byte(`@import "a.css";`)},
+	}
+	_, err = NewBundler(cyclic).Bundle("index.html")
+	assert.ErrorContains(t, err, "@import nesting")
+
+	_, err = (&Bundler{}).Bundle("index.html")
+	assert.Error(t, err)
+}
+
+func TestResolveAsset(t *testing.T) {
+	tests := []struct {
+		base, ref string
+		want      string
+		ok        bool
+	}{
+		{"ui/index.html", "app.js", "ui/app.js", true},
+		{"ui/css/app.css", "../img/a.png?v=1#x", "ui/img/a.png", true},
+		{"ui/index.html", "/static/a.css", "static/a.css", true},
+		{"ui/index.html", "https://example.com/a.js", "", false},
+		{"ui/index.html", "//cdn.example.com/a.js", "", false},
+		{"ui/index.html", "data:image/png;base64,AA", "", false},
+		{"ui/index.html", "#frag", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, ok := resolveAsset(tt.base, tt.ref)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEscapeRawText(t *testing.T) {
+	assert.Equal(t, `a<\/SCRIPT>b<\/script>`, escapeRawText(`a</SCRIPT>b</script>`, "script"))
+	assert.Equal(t, "plain", escapeRawText("plain", "style"))
+	assert.True(t, strings.HasPrefix(mimeTypeForPath("x.woff"), "font/woff"))
+}
+
+func TestMinifyCSS(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{".a :hover { color:  red ; }", ".a :hover{color:red}"},
+		{"ul > li ,\n p a:first-child {margin: 0 auto}", "ul > li,p a:first-child{margin:0 auto}"},
+		{`p::after { content: "a  ;  b /* c */ }"; }`, `p::after{content:"a  ;  b /* c */ }"}`},
+		{`div { background: url( "a  b.png" ) , url(c\).png) }`, `div{background:url( "a  b.png" ),url(c\).png)}`},
+		{"@media (min-width: 10px) { .a :focus { top: 0 } }", "@media (min-width: 10px){.a :focus{top:0}}"},
+		{`q { quotes: '\'  x' "y"; }`, `q{quotes:'\'  x' "y"}`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, minifyCSS(tt.in), tt.in)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// RemoteComponent describes a remote element that a host's component
+// library can render.
+type RemoteComponent struct {
+	// Name is the element name, e.g. "ui-button".
+	Name string `json:"name"`
+	// Props lists the attributes and properties the component accepts.
+	Props []string `json:"props,omitempty"`
+	// Events lists the events the component emits.
+	Events []string `json:"events,omitempty"`
+}
+
+// Validate checks that the component has a usable name, props and events.
+func (c *RemoteComponent) Validate() error {
+	if !validRemoteName(c.Name) {
+		return fmt.Errorf("invalid remote component name %q", c.Name)
+	}
+	for _, p := range c.Props {
+		if !validRemoteName(p) {
+			return fmt.Errorf("invalid prop %q on component %s", p, c.Name)
+		}
+	}
+	for _, e := range c.Events {
+		if !validRemoteName(e) {
+			return fmt.Errorf("invalid event %q on component %s", e, c.Name)
+		}
+	}
+	return nil
+}
+
+func (c *RemoteComponent) clone() *RemoteComponent {
+	return &RemoteComponent{Name: c.Name, Props: slices.Clone(c.Props), Events: slices.Clone(c.Events)}
+}
+
+// ComponentLibrary is a registry of the remote components a host library
+// provides. It is used to check Remote DOM trees before they are sent, so a
+// tree cannot reference components, props or events the host does not
+// provide, or target a framework the host library is not available in.
+// A ComponentLibrary is safe for concurrent use.
+type ComponentLibrary struct {
+	mu         sync.RWMutex
+	name       string
+	frameworks []Framework
+	components map[string]*RemoteComponent
+}
+
+// NewComponentLibrary creates an empty library named after the host library
+// it describes. Frameworks lists the Remote DOM frameworks the host library
+// is available in; none means any framework. Add components with
+// [ComponentLibrary.Register].
+func NewComponentLibrary(name string, frameworks ...Framework) *ComponentLibrary {
+	return &ComponentLibrary{
+		name:       name,
+		frameworks: slices.Clone(frameworks),
+		components: make(map[string]*RemoteComponent),
+	}
+}
+
+// BasicComponentLibrary returns a library matching the MCP-UI basic
+// component library shipped with the reference client for React and Web
+// Components: ui-text, ui-button, ui-image and ui-stack.
+func BasicComponentLibrary() *ComponentLibrary {
+	l := NewComponentLibrary("basic", FrameworkReact, FrameworkWebComponents)
+	for _, c := range []*RemoteComponent{
+		{Name: "ui-text", Props: []string{"content"}},
+		{Name: "ui-button", Props: []string{"label", "disabled"}, Events: []string{"press"}},
+		{Name: "ui-image", Props: []string{"src", "alt", "width", "height"}},
+		{Name: "ui-stack", Props: []string{"direction", "spacing", "align", "justify"}},
+	} {
+		l.components[c.Name] = c
+	}
+	return l
+}
+
+// Name returns the library name.
+func (l *ComponentLibrary) Name() string {
+	return l.name
+}
+
+// Frameworks returns the frameworks the library is available in, or nil if
+// it is not tied to a framework.
+func (l *ComponentLibrary) Frameworks() []Framework {
+	return slices.Clone(l.frameworks)
+}
+
+// CheckFramework returns an error if content for framework may be rendered
+// without the library. A library tied to frameworks requires one of them,
+// so the empty framework, which lets the host choose, is rejected.
+func (l *ComponentLibrary) CheckFramework(framework Framework) error {
+	if err := framework.Validate(); err != nil {
+		return err
+	}
+	if len(l.frameworks) == 0 || slices.Contains(l.frameworks, framework) {
+		return nil
+	}
+	if framework == "" {
+		return fmt.Errorf("the %s library requires a framework, one of %v", l.name, l.frameworks)
+	}
+	return fmt.Errorf("the %s library is not available in framework %q", l.name, framework)
+}
+
+// Register adds or replaces a component. The library keeps a copy of c.
+func (l *ComponentLibrary) Register(c *RemoteComponent) error {
+	if c == nil {
+		return errors.New("nil remote component")
+	}
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.components[c.Name] = c.clone()
+	return nil
+}
+
+// Component returns a copy of the named component.
+func (l *ComponentLibrary) Component(name string) (*RemoteComponent, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	c, ok := l.components[name]
+	if !ok {
+		return nil, false
+	}
+	return c.clone(), true
+}
+
+// Components returns copies of all components sorted by name.
+func (l *ComponentLibrary) Components() []*RemoteComponent {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	out := make([]*RemoteComponent, 0, len(l.components))
+	for _, name := range sortedKeys(l.components) {
+		out = append(out, l.components[name].clone())
+	}
+	return out
+}
+
+// Validate checks that every element in the trees is a component of the
+// library and only uses the props and events it declares.
+func (l *ComponentLibrary) Validate(nodes ...RemoteNode) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, n := range nodes {
+		if err := l.validateNode(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *ComponentLibrary) validateNode(n RemoteNode) error {
+	switch n := n.(type) {
+	case RemoteText:
+		return nil
+	case *RemoteElement:
+		if n == nil {
+			return errors.New("nil remote DOM element")
+		}
+		c, ok := l.components[n.Tag]
+		if !ok {
+			return fmt.Errorf("component %q is not provided by the %s library", n.Tag, l.name)
+		}
+		for _, prop := range sortedKeys(n.Attributes) {
+			if !slices.Contains(c.Props, prop) {
+				return fmt.Errorf("component %s does not accept prop %q", c.Name, prop)
+			}
+		}
+		for _, event := range sortedKeys(n.Events) {
+			if !slices.Contains(c.Events, event) {
+				return fmt.Errorf("component %s does not emit event %q", c.Name, event)
+			}
+		}
+		for _, child := range n.Children {
+			if err := l.validateNode(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case nil:
+		return errors.New("nil remote DOM node")
+	default:
+		return fmt.Errorf("unsupported remote DOM node %T", n)
+	}
+}
+
+// NewRemoteDOMContent checks the framework with
+// [ComponentLibrary.CheckFramework], validates the trees against the library
+// and renders them with [NewRemoteDOMContent].
+func (l *ComponentLibrary) NewRemoteDOMContent(framework Framework, nodes ...RemoteNode) (*RemoteDOMContent, error) {
+	if err := l.CheckFramework(framework); err != nil {
+		return nil, err
+	}
+	if err := l.Validate(nodes...); err != nil {
+		return nil, err
+	}
+	return NewRemoteDOMContent(framework, nodes...)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentLibrary_Register(t *testing.T) {
+	lib := NewComponentLibrary("custom")
+	assert.Equal(t, "custom", lib.Name())
+
+	require.NoError(t, lib.Register(&RemoteComponent{Name: "my-chart", Props: []string{"data"}, Events: []string{"select"}}))
+	assert.Error(t, lib.Register(nil))
+	assert.Error(t, lib.Register(&RemoteComponent{Name: ""}))
+	assert.Error(t, lib.Register(&RemoteComponent{Name: "x-y", Props: []string{"bad prop"}}))
+	assert.Error(t, lib.Register(&RemoteComponent{Name: "x-y", Events: []string{"on\"x"}}))
+
+	c, ok := lib.Component("my-chart")
+	require.True(t, ok)
+	assert.Equal(t, []string{"data"}, c.Props)
+
+	// Components are copied in and out.
+	c.Props[0] = "changed"
+	lib.Components()[0].Events[0] = "changed"
+	registered := &RemoteComponent{Name: "my-table", Props: []string{"rows"}}
+	require.NoError(t, lib.Register(registered))
+	registered.Props[0] = "changed"
+	c, _ = lib.Component("my-chart")
+	assert.Equal(t, []string{"data"}, c.Props)
+	assert.Equal(t, []string{"select"}, c.Events)
+	c, _ = lib.Component("my-table")
+	assert.Equal(t, []string{"rows"}, c.Props)
+
+	_, ok = lib.Component("missing")
+	assert.False(t, ok)
+}
+
+func TestBasicComponentLibrary(t *testing.T) {
+	lib := BasicComponentLibrary()
+	var names []string
+	for _, c := range lib.Components() {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"ui-button", "ui-image", "ui-stack", "ui-text"}, names)
+}
+
+func TestComponentLibrary_Validate(t *testing.T) {
+	lib := BasicComponentLibrary()
+	press, _ := NewToolAction("", "go", nil)
+
+	tests := []struct {
+		name    string
+		node    RemoteNode
+		wantErr string
+	}{
+		{
+			name: "valid tree",
+			node: NewRemoteElement("ui-stack",
+				NewRemoteElement("ui-text").SetAttribute("content", "hi"),
+				NewRemoteElement("ui-button").SetAttribute("label", "Go").On("press", press),
+				RemoteText("plain"),
+			).SetAttribute("direction", "horizontal"),
+		},
+		{
+			name:    "unknown component",
+			node:    NewRemoteElement("ui-stack", NewRemoteElement("ui-video")),
+			wantErr: `component "ui-video" is not provided by the basic library`,
+		},
+		{
+			name:    "unknown prop",
+			node:    NewRemoteElement("ui-text").SetAttribute("color", "red"),
+			wantErr: `component ui-text does not accept prop "color"`,
+		},
+		{
+			name:    "unknown event",
+			node:    NewRemoteElement("ui-image").On("press", press),
+			wantErr: `component ui-image does not emit event "press"`,
+		},
+		{
+			name:    "nil node",
+			node:    nil,
+			wantErr: "nil remote DOM node",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := lib.Validate(tt.node)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComponentLibrary_NewRemoteDOMContent(t *testing.T) {
+	lib := BasicComponentLibrary()
+
+	content, err := lib.NewRemoteDOMContent(FrameworkReact, NewRemoteElement("ui-text").SetAttribute("content", "hi"))
+	require.NoError(t, err)
+	assert.NoError(t, content.Validate())
+
+	_, err = lib.NewRemoteDOMContent(FrameworkReact, NewRemoteElement("div"))
+	assert.Error(t, err)
+
+	_, err = lib.NewRemoteDOMContent("vue", NewRemoteElement("ui-text"))
+	assert.Error(t, err)
+}
+
+func TestComponentLibrary_Frameworks(t *testing.T) {
+	text := NewRemoteElement("ui-text").SetAttribute("content", "hi")
+
+	basic := BasicComponentLibrary()
+	assert.Equal(t, []Framework{FrameworkReact, FrameworkWebComponents}, basic.Frameworks())
+	_, err := basic.NewRemoteDOMContent(FrameworkWebComponents, text)
+	assert.NoError(t, err)
+	_, err = basic.NewRemoteDOMContent("", text)
+	assert.ErrorContains(t, err, "requires a framework")
+
+	reactOnly := NewComponentLibrary("charts", FrameworkReact)
+	require.NoError(t, reactOnly.Register(&RemoteComponent{Name: "ui-text", Props: []string{"content"}}))
+	_, err = reactOnly.NewRemoteDOMContent(FrameworkReact, text)
+	assert.NoError(t, err)
+	_, err = reactOnly.NewRemoteDOMContent(FrameworkWebComponents, text)
+	assert.ErrorContains(t, err, `the charts library is not available in framework "webcomponents"`)
+
+	anyFramework := NewComponentLibrary("custom")
+	assert.Nil(t, anyFramework.Frameworks())
+	assert.NoError(t, anyFramework.CheckFramework(""))
+	assert.NoError(t, anyFramework.CheckFramework(FrameworkWebComponents))
+	assert.Error(t, anyFramework.CheckFramework("vue"))
+}
@@ -29,9 +29,31 @@ const (
 	FrameworkWebComponents Framework = "webcomponents"
 )
 
+// Validate checks that the framework is one of the known frameworks.
+// The empty framework is valid and means the host chooses.
+func (f Framework) Validate() error {
+	switch f {
+	case "", FrameworkReact, FrameworkWebComponents:
+		return nil
+	default:
+		return fmt.Errorf("unknown Remote DOM framework %q", string(f))
+	}
+}
+
 // URIScheme is the URI scheme for UI resources.
 const URIScheme = "ui://"
 
+// Encoding selects how textual UI content is carried on the wire.
+type Encoding string
+
+const (
+	// EncodingText carries content in the "text" field. This is the default.
+	EncodingText Encoding = "text"
+	// EncodingBlob carries content base64-encoded in the "blob" field,
+	// matching the TypeScript SDK's encoding: 'blob' option.
+	EncodingBlob Encoding = "blob"
+)
+
 // Annotations contains metadata annotations for UI content.
 // This mirrors the annotations concept from the MCP protocol.
 type Annotations struct {
@@ -41,7 +63,8 @@ type Annotations struct {
 	Priority *float64 `json:"priority,omitempty"`
 }
 
-// UIContent is an [HTMLContent], [URLContent], or [RemoteDOMContent].
+// UIContent is an [HTMLContent], [MCPAppContent], [SkybridgeContent],
+// [URLContent], or [RemoteDOMContent].
 // This interface mirrors mcp.Content for UI resources.
 type UIContent interface {
 	// MarshalJSON serializes the content to JSON wire format.
@@ -59,50 +82,113 @@ type UIContent interface {
 // # Security
 //
 // This content is rendered in a sandboxed iframe with restricted permissions.
-// However, the HTML is NOT sanitized by this SDK. Clients MUST ensure the
+// However, the HTML is NOT sanitized automatically. Clients MUST ensure the
 // iframe uses appropriate sandbox attributes (e.g., "allow-scripts" only when
 // necessary) and implements Content Security Policy (CSP) headers. Server
-// implementations should validate and sanitize HTML content before including
-// it in responses.
+// implementations should sanitize untrusted HTML with a [SanitizePolicy]
+// before including it in responses. Use [CSPPolicy] to embed a policy in the
+// HTML itself.
 type HTMLContent struct {
 	// HTML is the inline HTML content to render.
 	HTML string
+	// Charset is the optional charset MIME parameter (e.g., "utf-8").
+	Charset string
+	// Profile is the optional profile MIME parameter. The "mcp-app"
+	// profile is reserved for [MCPAppContent] and rejected.
+	Profile string
+	// MIMEParams holds any other MIME type parameters, keyed by lower-case
+	// name. Charset and Profile take precedence over entries of the same
+	// name.
+	MIMEParams map[string]string
+	// Encoding selects text or blob encoding on the wire (default text).
+	Encoding Encoding
 	// Annotations contains optional metadata.
 	Annotations *Annotations
 }
 
 // MarshalJSON serializes HTMLContent to the wire format.
+// Content with the mcp-app profile decodes as [MCPAppContent], so that
+// profile is rejected.
 func (c *HTMLContent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&wireUIContent{
-		MIMEType:    MIMETypeHTML,
-		Text:        c.HTML,
-		Annotations: c.Annotations,
-	})
+	if strings.EqualFold(c.Profile, ProfileMCPApp) {
+		return nil, fmt.Errorf("HTMLContent cannot use the %s profile, use MCPAppContent", ProfileMCPApp)
+	}
+	return marshalText(c.mimeType(), c.HTML, c.Encoding, c.Annotations)
 }
 
-func (c *HTMLContent) mimeType() string { return MIMETypeHTML }
+func (c *HTMLContent) mimeType() string {
+	return newMediaType(MIMETypeHTML, c.MIMEParams, MIMEParamCharset, c.Charset, MIMEParamProfile, c.Profile).String()
+}
 
 func (c *HTMLContent) fromWire(wire *wireUIContent) error {
-	c.HTML = wire.Text
+	text, enc, err := wire.text()
+	if err != nil {
+		return err
+	}
+	params := wireParams(wire.MIMEType)
+	c.HTML = text
+	c.Encoding = enc
+	c.Charset = params[MIMEParamCharset]
+	c.Profile = params[MIMEParamProfile]
+	c.MIMEParams = otherParams(params, MIMEParamCharset, MIMEParamProfile)
 	c.Annotations = wire.Annotations
 	return nil
 }
 
 // URLContent contains an external URL to render in an iframe.
 // The URL is loaded using the iframe's src attribute.
+//
+// The wire format is a text/uri-list (RFC 2483): one URL per line, with
+// lines starting with '#' treated as comments. MCP-UI hosts load the first
+// valid URL, so URL is the primary URL and Fallbacks are tried in order by
+// hosts that support them.
 type URLContent struct {
-	// URL is the external URL to load.
+	// URL is the primary external URL to load.
 	URL string
+	// Fallbacks are alternative URLs listed after the primary URL.
+	Fallbacks []string
+	// Comments are the comment lines of the list, without the leading '#'.
+	Comments []string
+	// Charset is the optional charset MIME parameter (e.g., "utf-8").
+	Charset string
+	// MIMEParams holds any other MIME type parameters, keyed by lower-case
+	// name. Charset takes precedence over an entry of the same name.
+	MIMEParams map[string]string
+	// Encoding selects text or blob encoding on the wire (default text).
+	Encoding Encoding
 	// Annotations contains optional metadata.
 	Annotations *Annotations
 }
 
-// Validate checks that the URLContent has a valid URL.
+// URLs returns the primary URL followed by the fallbacks.
+func (c *URLContent) URLs() []string {
+	urls := make([]string, 0, 1+len(c.Fallbacks))
+	if c.URL != "" {
+		urls = append(urls, c.URL)
+	}
+	return append(urls, c.Fallbacks...)
+}
+
+// Validate checks that the URLContent has a valid primary URL and that
+// every fallback URL is valid.
 func (c *URLContent) Validate() error {
 	if c.URL == "" {
 		return fmt.Errorf("URLContent URL is required")
 	}
-	parsed, err := url.Parse(c.URL)
+	if err := validateHTTPURL(c.URL); err != nil {
+		return err
+	}
+	for i, fallback := range c.Fallbacks {
+		if err := validateHTTPURL(fallback); err != nil {
+			return fmt.Errorf("fallback URL %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validateHTTPURL checks that rawURL is an absolute http or https URL.
+func validateHTTPURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
 	if err != nil {
 		return fmt.Errorf("invalid URL: %w", err)
 	}
@@ -117,17 +203,46 @@ func (c *URLContent) Validate() error {
 
 // MarshalJSON serializes URLContent to the wire format.
 func (c *URLContent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&wireUIContent{
-		MIMEType:    MIMETypeURLList,
-		Text:        c.URL,
-		Annotations: c.Annotations,
-	})
+	return marshalText(c.mimeType(), c.uriList(), c.Encoding, c.Annotations)
 }
 
-func (c *URLContent) mimeType() string { return MIMETypeURLList }
+func (c *URLContent) mimeType() string {
+	return newMediaType(MIMETypeURLList, c.MIMEParams, MIMEParamCharset, c.Charset).String()
+}
+
+// uriList formats the content as a text/uri-list: comments first, then the
+// primary URL, then the fallbacks, separated by CRLF.
+func (c *URLContent) uriList() string {
+	lines := make([]string, 0, len(c.Comments)+1+len(c.Fallbacks))
+	for _, comment := range c.Comments {
+		lines = append(lines, "#"+comment)
+	}
+	lines = append(lines, c.URLs()...)
+	return strings.Join(lines, "\r\n")
+}
 
 func (c *URLContent) fromWire(wire *wireUIContent) error {
-	c.URL = wire.Text
+	text, enc, err := wire.text()
+	if err != nil {
+		return err
+	}
+	c.URL, c.Fallbacks, c.Comments = "", nil, nil
+	c.Encoding = enc
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#"):
+			c.Comments = append(c.Comments, line[1:])
+		case c.URL == "":
+			c.URL = line
+		default:
+			c.Fallbacks = append(c.Fallbacks, line)
+		}
+	}
+	params := wireParams(wire.MIMEType)
+	c.Charset = params[MIMEParamCharset]
+	c.MIMEParams = otherParams(params, MIMEParamCharset)
 	c.Annotations = wire.Annotations
 	return nil
 }
@@ -149,44 +264,57 @@ type RemoteDOMContent struct {
 	Script string
 	// Framework specifies the rendering framework (React or WebComponents).
 	Framework Framework
+	// Charset is the optional charset MIME parameter (e.g., "utf-8").
+	Charset string
+	// MIMEParams holds any other MIME type parameters, keyed by lower-case
+	// name. Framework and Charset take precedence over entries of the same
+	// name.
+	MIMEParams map[string]string
+	// Encoding selects text or blob encoding on the wire (default text).
+	Encoding Encoding
 	// Annotations contains optional metadata.
 	Annotations *Annotations
 }
 
-// MarshalJSON serializes RemoteDOMContent to the wire format.
+// Validate checks that the RemoteDOMContent has a script and a known framework.
+func (c *RemoteDOMContent) Validate() error {
+	if c.Script == "" {
+		return fmt.Errorf("RemoteDOMContent Script is required")
+	}
+	return c.Framework.Validate()
+}
+
+// MarshalJSON serializes RemoteDOMContent to the wire format. Content that
+// fails [RemoteDOMContent.Validate] is rejected.
 func (c *RemoteDOMContent) MarshalJSON() ([]byte, error) {
-	mimeType := MIMETypeRemoteDOM + "+javascript"
-	if c.Framework != "" {
-		mimeType += "; framework=" + string(c.Framework)
+	if err := c.Validate(); err != nil {
+		return nil, err
 	}
-	return json.Marshal(&wireUIContent{
-		MIMEType:    mimeType,
-		Text:        c.Script,
-		Annotations: c.Annotations,
-	})
+	return marshalText(c.mimeType(), c.Script, c.Encoding, c.Annotations)
 }
 
 func (c *RemoteDOMContent) mimeType() string {
-	mimeType := MIMETypeRemoteDOM + "+javascript"
-	if c.Framework != "" {
-		mimeType += "; framework=" + string(c.Framework)
-	}
-	return mimeType
+	return newMediaType(MIMETypeRemoteDOM+"+javascript", c.MIMEParams,
+		MIMEParamCharset, c.Charset,
+		MIMEParamFramework, string(c.Framework),
+	).String()
 }
 
 func (c *RemoteDOMContent) fromWire(wire *wireUIContent) error {
-	c.Script = wire.Text
-	c.Annotations = wire.Annotations
-	// Parse framework from MIME type (e.g., "application/vnd.mcp-ui.remote-dom+javascript; framework=react")
-	if idx := strings.Index(wire.MIMEType, "framework="); idx != -1 {
-		frameworkPart := wire.MIMEType[idx+len("framework="):]
-		// Trim any trailing parameters (e.g., "; other=value")
-		if endIdx := strings.IndexAny(frameworkPart, "; "); endIdx != -1 {
-			frameworkPart = frameworkPart[:endIdx]
-		}
-		c.Framework = Framework(frameworkPart)
+	// Parameters are parsed from the MIME type, e.g.
+	// "application/vnd.mcp-ui.remote-dom+javascript; framework=react".
+	text, enc, err := wire.text()
+	if err != nil {
+		return err
 	}
-	return nil
+	params := wireParams(wire.MIMEType)
+	c.Script = text
+	c.Encoding = enc
+	c.Framework = Framework(params[MIMEParamFramework])
+	c.Charset = params[MIMEParamCharset]
+	c.MIMEParams = otherParams(params, MIMEParamCharset, MIMEParamFramework)
+	c.Annotations = wire.Annotations
+	return c.Validate()
 }
 
 // BlobContent contains binary data (base64-encoded) for UI resources.
@@ -194,23 +322,36 @@ func (c *RemoteDOMContent) fromWire(wire *wireUIContent) error {
 type BlobContent struct {
 	// Data is the binary content.
 	Data []byte
-	// MIMEType is the MIME type of the binary content.
+	// ContentMIMEType is the MIME type of the binary content, with any
+	// parameters. It is serialized in the canonical form of
+	// [MediaType.String], keeping every parameter, or as is if it cannot be
+	// parsed.
 	ContentMIMEType string
 	// Annotations contains optional metadata.
 	Annotations *Annotations
 }
 
+// MediaType parses ContentMIMEType, exposing its parameters.
+func (c *BlobContent) MediaType() (MediaType, error) {
+	return ParseMediaType(c.ContentMIMEType)
+}
+
 // MarshalJSON serializes BlobContent to the wire format.
 func (c *BlobContent) MarshalJSON() ([]byte, error) {
 	encoded := base64.StdEncoding.EncodeToString(c.Data)
 	return json.Marshal(&wireUIContent{
-		MIMEType:    c.ContentMIMEType,
+		MIMEType:    c.mimeType(),
 		Blob:        encoded,
 		Annotations: c.Annotations,
 	})
 }
 
-func (c *BlobContent) mimeType() string { return c.ContentMIMEType }
+func (c *BlobContent) mimeType() string {
+	if mt, err := c.MediaType(); err == nil {
+		return mt.String()
+	}
+	return c.ContentMIMEType
+}
 
 func (c *BlobContent) fromWire(wire *wireUIContent) error {
 	if wire.Blob != "" {
@@ -228,32 +369,85 @@ func (c *BlobContent) fromWire(wire *wireUIContent) error {
 // wireUIContent is the wire format for UI content.
 // It represents all content types in a single structure for JSON marshaling.
 type wireUIContent struct {
-	MIMEType    string       `json:"mimeType"`
-	Text        string       `json:"text,omitempty"`
-	Blob        string       `json:"blob,omitempty"`
-	Annotations *Annotations `json:"annotations,omitempty"`
+	MIMEType    string         `json:"mimeType"`
+	Text        string         `json:"text,omitempty"`
+	Blob        string         `json:"blob,omitempty"`
+	Annotations *Annotations   `json:"annotations,omitempty"`
+	Meta        map[string]any `json:"_meta,omitempty"`
+}
+
+// marshalText serializes textual content, placing it in the text or blob
+// field according to enc.
+func marshalText(mimeType, text string, enc Encoding, annotations *Annotations) ([]byte, error) {
+	wire := &wireUIContent{
+		MIMEType:    mimeType,
+		Annotations: annotations,
+	}
+	switch enc {
+	case "", EncodingText:
+		wire.Text = text
+	case EncodingBlob:
+		wire.Blob = base64.StdEncoding.EncodeToString([]byte(text))
+	default:
+		return nil, fmt.Errorf("unknown content encoding: %s", enc)
+	}
+	return json.Marshal(wire)
+}
+
+// text returns the textual payload of the wire content, decoding a base64
+// blob if present, and reports which encoding was used.
+func (w *wireUIContent) text() (string, Encoding, error) {
+	if w.Blob == "" {
+		return w.Text, "", nil
+	}
+	data, err := base64.StdEncoding.DecodeString(w.Blob)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode base64 blob: %w", err)
+	}
+	return string(data), EncodingBlob, nil
 }
 
 // ContentFromWire converts wire format to the appropriate UIContent type.
+// The MIME type is parsed with [ParseMediaType], so type names are matched
+// case-insensitively and parameters such as charset may appear in any order.
+// Content is dispatched on MIME type whether it arrives as text or as a
+// blob; only unrecognized MIME types with a blob become [BlobContent].
 func ContentFromWire(wire *wireUIContent) (UIContent, error) {
 	if wire == nil {
 		return nil, fmt.Errorf("nil wire content")
 	}
 
+	mt, err := ParseMediaType(wire.MIMEType)
+	if err != nil && wire.Blob == "" {
+		return nil, fmt.Errorf("unknown content MIME type: %w", err)
+	}
+
 	switch {
-	case wire.MIMEType == MIMETypeHTML:
+	case mt.Type == MIMETypeHTML && strings.EqualFold(mt.Param(MIMEParamProfile), ProfileMCPApp):
+		c := &MCPAppContent{}
+		if err := c.fromWire(wire); err != nil {
+			return nil, err
+		}
+		return c, nil
+	case mt.Type == MIMETypeHTML:
 		c := &HTMLContent{}
 		if err := c.fromWire(wire); err != nil {
 			return nil, err
 		}
 		return c, nil
-	case wire.MIMEType == MIMETypeURLList:
+	case mt.Type == MIMETypeSkybridge:
+		c := &SkybridgeContent{}
+		if err := c.fromWire(wire); err != nil {
+			return nil, err
+		}
+		return c, nil
+	case mt.Type == MIMETypeURLList:
 		c := &URLContent{}
 		if err := c.fromWire(wire); err != nil {
 			return nil, err
 		}
 		return c, nil
-	case len(wire.MIMEType) >= len(MIMETypeRemoteDOM) && wire.MIMEType[:len(MIMETypeRemoteDOM)] == MIMETypeRemoteDOM:
+	case strings.HasPrefix(mt.Type, MIMETypeRemoteDOM):
 		c := &RemoteDOMContent{}
 		if err := c.fromWire(wire); err != nil {
 			return nil, err
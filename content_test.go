@@ -259,6 +259,7 @@ func TestContentFromWire(t *testing.T) {
 				dom, ok := c.(*RemoteDOMContent)
 				require.True(t, ok, "expected RemoteDOMContent")
 				assert.Equal(t, "React.render();", dom.Script)
+				assert.Equal(t, FrameworkReact, dom.Framework)
 			},
 		},
 		{
@@ -279,6 +280,71 @@ func TestContentFromWire(t *testing.T) {
 			wire:    nil,
 			wantErr: true,
 		},
+		{
+			name: "HTML with charset",
+			wire: &wireUIContent{
+				MIMEType: "text/html; charset=utf-8",
+				Text:     "<p>x</p>",
+			},
+			check: func(t *testing.T, c UIContent) {
+				html, ok := c.(*HTMLContent)
+				require.True(t, ok, "expected HTMLContent")
+				assert.Equal(t, "utf-8", html.Charset)
+			},
+		},
+		{
+			name: "HTML with mixed case and profile",
+			wire: &wireUIContent{
+				MIMEType: "TEXT/HTML;Profile=custom",
+				Text:     "<p>x</p>",
+			},
+			check: func(t *testing.T, c UIContent) {
+				html, ok := c.(*HTMLContent)
+				require.True(t, ok, "expected HTMLContent")
+				assert.Equal(t, "custom", html.Profile)
+			},
+		},
+		{
+			name: "URL with charset",
+			wire: &wireUIContent{
+				MIMEType: "text/uri-list;charset=us-ascii",
+				Text:     "https://example.com",
+			},
+			check: func(t *testing.T, c UIContent) {
+				url, ok := c.(*URLContent)
+				require.True(t, ok, "expected URLContent")
+				assert.Equal(t, "us-ascii", url.Charset)
+			},
+		},
+		{
+			name: "RemoteDOM with reordered quoted parameters",
+			wire: &wireUIContent{
+				MIMEType: `application/vnd.mcp-ui.remote-dom+javascript;charset=utf-8; FRAMEWORK="webcomponents"`,
+				Text:     "x",
+			},
+			check: func(t *testing.T, c UIContent) {
+				dom, ok := c.(*RemoteDOMContent)
+				require.True(t, ok, "expected RemoteDOMContent")
+				assert.Equal(t, FrameworkWebComponents, dom.Framework)
+				assert.Equal(t, "utf-8", dom.Charset)
+			},
+		},
+		{
+			name: "malformed MIME type",
+			wire: &wireUIContent{
+				MIMEType: "text/html; charset",
+				Text:     "x",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown MIME type",
+			wire: &wireUIContent{
+				MIMEType: "text/plain",
+				Text:     "x",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -335,6 +401,230 @@ func TestRemoteDOMContent_MimeType(t *testing.T) {
 	}
 }
 
+func TestContent_MIMEParamsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  UIContent
+		wantMIME string
+	}{
+		{
+			name:     "HTML charset and profile",
+			content:  &HTMLContent{HTML: "<p>x</p>", Charset: "utf-8", Profile: "custom"},
+			wantMIME: "text/html; charset=utf-8; profile=custom",
+		},
+		{
+			name:     "URL charset",
+			content:  &URLContent{URL: "https://example.com", Charset: "utf-8"},
+			wantMIME: "text/uri-list; charset=utf-8",
+		},
+		{
+			name:     "RemoteDOM charset and framework",
+			content:  &RemoteDOMContent{Script: "x", Framework: FrameworkReact, Charset: "utf-8"},
+			wantMIME: "application/vnd.mcp-ui.remote-dom+javascript; charset=utf-8; framework=react",
+		},
+		{
+			name:     "HTML other parameters",
+			content:  &HTMLContent{HTML: "<p>x</p>", Charset: "utf-8", MIMEParams: map[string]string{"version": "2", "title": "a b"}},
+			wantMIME: `text/html; charset=utf-8; title="a b"; version=2`,
+		},
+		{
+			name:     "URL other parameters",
+			content:  &URLContent{URL: "https://example.com", MIMEParams: map[string]string{"version": "2"}},
+			wantMIME: "text/uri-list; version=2",
+		},
+		{
+			name:     "RemoteDOM other parameters",
+			content:  &RemoteDOMContent{Script: "x", Framework: FrameworkReact, MIMEParams: map[string]string{"version": "2"}},
+			wantMIME: "application/vnd.mcp-ui.remote-dom+javascript; framework=react; version=2",
+		},
+		{
+			name:     "blob parameters",
+			content:  &BlobContent{Data: []byte("<svg/>"), ContentMIMEType: "image/svg+xml; charset=utf-8; version=2"},
+			wantMIME: "image/svg+xml; charset=utf-8; version=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := NewUIResourceContents("ui://test/x", tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMIME, rc.MIMEType)
+
+			back, err := rc.ToUIContent()
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, back)
+		})
+	}
+}
+
+func TestContent_MIMEParams(t *testing.T) {
+	// Dedicated fields take precedence over MIMEParams.
+	html := &HTMLContent{HTML: "x", MIMEParams: map[string]string{"charset": "latin1", "Version": "2"}}
+	assert.Equal(t, "text/html; version=2", html.mimeType())
+
+	c, err := ContentFromWire(&wireUIContent{MIMEType: `text/html; Version="2"; charset=utf-8`, Text: "x"})
+	require.NoError(t, err)
+	assert.Equal(t, &HTMLContent{HTML: "x", Charset: "utf-8", MIMEParams: map[string]string{"version": "2"}}, c)
+
+	blob := &BlobContent{Data: []byte{1}, ContentMIMEType: `Image/PNG;Q="1"`}
+	mt, err := blob.MediaType()
+	require.NoError(t, err)
+	assert.Equal(t, "1", mt.Param("q"))
+	rc, err := NewUIResourceContents("ui://x", blob)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png; q=1", rc.MIMEType)
+
+	// Unparseable blob MIME types are kept as is.
+	rc, err = NewUIResourceContents("ui://x", &BlobContent{Data: []byte{1}, ContentMIMEType: "not a type"})
+	require.NoError(t, err)
+	assert.Equal(t, "not a type", rc.MIMEType)
+}
+
+func TestHTMLContent_RejectsMCPAppProfile(t *testing.T) {
+	for _, profile := range []string{ProfileMCPApp, "MCP-App"} {
+		_, err := NewUIResourceContents("ui://x", &HTMLContent{HTML: "x", Profile: profile})
+		assert.ErrorContains(t, err, "MCPAppContent")
+	}
+	_, err := NewUIResourceContents("ui://x", &HTMLContent{HTML: "x", MIMEParams: map[string]string{"profile": ProfileMCPApp}})
+	assert.NoError(t, err, "MIMEParams cannot set the profile")
+}
+
+func TestURLContent_URIList(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		wire := &wireUIContent{
+			MIMEType: MIMETypeURLList,
+			Text:     "# primary dashboard\r\nhttps://a.example.com/dash\r\n\r\n#mirror\r\nhttps://b.example.com/dash\nhttps://c.example.com/dash\r\n",
+		}
+		c, err := ContentFromWire(wire)
+		require.NoError(t, err)
+		u := c.(*URLContent)
+		assert.Equal(t, "https://a.example.com/dash", u.URL)
+		assert.Equal(t, []string{"https://b.example.com/dash", "https://c.example.com/dash"}, u.Fallbacks)
+		assert.Equal(t, []string{" primary dashboard", "mirror"}, u.Comments)
+		assert.Equal(t, []string{"https://a.example.com/dash", "https://b.example.com/dash", "https://c.example.com/dash"}, u.URLs())
+	})
+
+	t.Run("serialize", func(t *testing.T) {
+		u := &URLContent{
+			URL:       "https://a.example.com",
+			Fallbacks: []string{"https://b.example.com"},
+			Comments:  []string{" generated"},
+		}
+		data, err := u.MarshalJSON()
+		require.NoError(t, err)
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(data, &m))
+		assert.Equal(t, "# generated\r\nhttps://a.example.com\r\nhttps://b.example.com", m["text"])
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		u := &URLContent{
+			URL:       "https://a.example.com",
+			Fallbacks: []string{"https://b.example.com", "https://c.example.com"},
+			Comments:  []string{" note"},
+		}
+		rc, err := NewUIResourceContents("ui://dash", u)
+		require.NoError(t, err)
+		back, err := rc.ToUIContent()
+		require.NoError(t, err)
+		assert.Equal(t, u, back)
+	})
+
+	t.Run("validate fallbacks", func(t *testing.T) {
+		u := &URLContent{URL: "https://a.example.com", Fallbacks: []string{"https://b.example.com", "ftp://c.example.com"}}
+		err := u.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fallback URL 2")
+
+		u.Fallbacks = u.Fallbacks[:1]
+		assert.NoError(t, u.Validate())
+	})
+}
+
+func TestContent_BlobEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		content UIContent
+	}{
+		{
+			name:    "HTML",
+			content: &HTMLContent{HTML: "<p>Hello</p>", Encoding: EncodingBlob},
+		},
+		{
+			name:    "URL",
+			content: &URLContent{URL: "https://example.com", Fallbacks: []string{"https://b.example.com"}, Encoding: EncodingBlob},
+		},
+		{
+			name:    "RemoteDOM",
+			content: &RemoteDOMContent{Script: "root.appendChild(x);", Framework: FrameworkReact, Encoding: EncodingBlob},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.content.MarshalJSON()
+			require.NoError(t, err)
+			var m map[string]any
+			require.NoError(t, json.Unmarshal(data, &m))
+			_, hasText := m["text"]
+			assert.False(t, hasText, "blob-encoded content should not include text")
+			assert.NotEmpty(t, m["blob"])
+
+			rc, err := NewUIResourceContents("ui://test/blob", tt.content)
+			require.NoError(t, err)
+			assert.NotNil(t, rc.Blob)
+			assert.Empty(t, rc.Text)
+
+			back, err := rc.ToUIContent()
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, back)
+		})
+	}
+}
+
+func TestContentFromWire_BlobHTML(t *testing.T) {
+	// base64 of "<h1>Hi</h1>"
+	c, err := ContentFromWire(&wireUIContent{MIMEType: "text/html", Blob: "PGgxPkhpPC9oMT4="})
+	require.NoError(t, err)
+	html, ok := c.(*HTMLContent)
+	require.True(t, ok, "expected HTMLContent, got %T", c)
+	assert.Equal(t, "<h1>Hi</h1>", html.HTML)
+	assert.Equal(t, EncodingBlob, html.Encoding)
+
+	_, err = ContentFromWire(&wireUIContent{MIMEType: "text/html", Blob: "not base64!"})
+	assert.Error(t, err)
+}
+
+func TestContent_UnknownEncoding(t *testing.T) {
+	_, err := (&HTMLContent{HTML: "x", Encoding: "gzip"}).MarshalJSON()
+	assert.Error(t, err)
+}
+
+func TestFramework_Validate(t *testing.T) {
+	assert.NoError(t, Framework("").Validate())
+	assert.NoError(t, FrameworkReact.Validate())
+	assert.NoError(t, FrameworkWebComponents.Validate())
+	assert.Error(t, Framework("vue").Validate())
+	assert.Error(t, Framework("React").Validate())
+}
+
+func TestRemoteDOMContent_Validate(t *testing.T) {
+	assert.NoError(t, (&RemoteDOMContent{Script: "x", Framework: FrameworkReact}).Validate())
+	assert.NoError(t, (&RemoteDOMContent{Script: "x"}).Validate())
+	assert.Error(t, (&RemoteDOMContent{Framework: FrameworkReact}).Validate())
+	assert.Error(t, (&RemoteDOMContent{Script: "x", Framework: "svelte"}).Validate())
+
+	_, err := NewUIResourceContents("ui://x", &RemoteDOMContent{Script: "x", Framework: "svelte"})
+	assert.ErrorContains(t, err, "svelte", "marshaling validates")
+	_, err = json.Marshal(&RemoteDOMContent{})
+	assert.ErrorContains(t, err, "Script is required")
+
+	_, err = ContentFromWire(&wireUIContent{MIMEType: "application/vnd.mcp-ui.remote-dom+javascript; framework=svelte", Text: "x"})
+	assert.ErrorContains(t, err, "svelte", "decoding validates")
+	_, err = ContentFromWire(&wireUIContent{MIMEType: "application/vnd.mcp-ui.remote-dom+javascript"})
+	assert.ErrorContains(t, err, "Script is required")
+}
+
 func TestConstants(t *testing.T) {
 	// Verify constants match MCP-UI specification
 	assert.Equal(t, "text/html", MIMETypeHTML)
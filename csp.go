@@ -0,0 +1,329 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"html"
+	"slices"
+	"strings"
+)
+
+// Common Content Security Policy source expressions.
+const (
+	// CSPSelf matches the document's own origin.
+	CSPSelf = "'self'"
+	// CSPNone matches nothing.
+	CSPNone = "'none'"
+	// CSPUnsafeInline allows inline scripts or styles.
+	CSPUnsafeInline = "'unsafe-inline'"
+	// CSPUnsafeEval allows eval and similar constructs.
+	CSPUnsafeEval = "'unsafe-eval'"
+	// CSPStrictDynamic propagates trust from nonced or hashed scripts to the scripts they load.
+	CSPStrictDynamic = "'strict-dynamic'"
+	// CSPData matches data: URLs.
+	CSPData = "data:"
+)
+
+// CSPPolicy builds a Content Security Policy for [HTMLContent].
+//
+// Because HTMLContent is rendered via iframe srcdoc, the server cannot set
+// HTTP response headers for it. Instead, [CSPPolicy.Apply] injects the policy
+// as a <meta http-equiv="Content-Security-Policy"> element. Directives that
+// browsers ignore in meta elements (frame-ancestors, report-uri, sandbox)
+// should be enforced by the host instead.
+//
+// Example:
+//
+//	policy := mcpui.NewCSPPolicy().
+//		DefaultSrc(mcpui.CSPNone).
+//		StyleSrc(mcpui.CSPUnsafeInline).
+//		ImgSrc(mcpui.CSPData).
+//		HashInlineScripts()
+//	secured, err := policy.Apply(content)
+type CSPPolicy struct {
+	directives  []cspDirective
+	nonce       string
+	hashScripts bool
+}
+
+type cspDirective struct {
+	name    string
+	sources []string
+}
+
+// NewCSPPolicy creates an empty policy.
+func NewCSPPolicy() *CSPPolicy {
+	return &CSPPolicy{}
+}
+
+// NewCSPNonce returns a fresh random nonce suitable for [CSPPolicy.WithNonce].
+func NewCSPNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Directive appends sources to the named directive, creating it if needed.
+// Duplicate sources are ignored.
+func (p *CSPPolicy) Directive(name string, sources ...string) *CSPPolicy {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i := range p.directives {
+		if p.directives[i].name == name {
+			for _, src := range sources {
+				if !slices.Contains(p.directives[i].sources, src) {
+					p.directives[i].sources = append(p.directives[i].sources, src)
+				}
+			}
+			return p
+		}
+	}
+	d := cspDirective{name: name}
+	for _, src := range sources {
+		if !slices.Contains(d.sources, src) {
+			d.sources = append(d.sources, src)
+		}
+	}
+	p.directives = append(p.directives, d)
+	return p
+}
+
+// DefaultSrc appends sources to the default-src directive.
+func (p *CSPPolicy) DefaultSrc(sources ...string) *CSPPolicy {
+	return p.Directive("default-src", sources...)
+}
+
+// ScriptSrc appends sources to the script-src directive.
+func (p *CSPPolicy) ScriptSrc(sources ...string) *CSPPolicy {
+	return p.Directive("script-src", sources...)
+}
+
+// StyleSrc appends sources to the style-src directive.
+func (p *CSPPolicy) StyleSrc(sources ...string) *CSPPolicy {
+	return p.Directive("style-src", sources...)
+}
+
+// ConnectSrc appends sources to the connect-src directive.
+func (p *CSPPolicy) ConnectSrc(sources ...string) *CSPPolicy {
+	return p.Directive("connect-src", sources...)
+}
+
+// ImgSrc appends sources to the img-src directive.
+func (p *CSPPolicy) ImgSrc(sources ...string) *CSPPolicy {
+	return p.Directive("img-src", sources...)
+}
+
+// FontSrc appends sources to the font-src directive.
+func (p *CSPPolicy) FontSrc(sources ...string) *CSPPolicy {
+	return p.Directive("font-src", sources...)
+}
+
+// FrameSrc appends sources to the frame-src directive.
+func (p *CSPPolicy) FrameSrc(sources ...string) *CSPPolicy {
+	return p.Directive("frame-src", sources...)
+}
+
+// WithNonce sets the nonce that [CSPPolicy.Apply] adds to inline scripts
+// and styles. Use [NewCSPNonce] to generate one per response.
+func (p *CSPPolicy) WithNonce(nonce string) *CSPPolicy {
+	p.nonce = nonce
+	return p
+}
+
+// Nonce returns the configured nonce, if any.
+func (p *CSPPolicy) Nonce() string {
+	return p.nonce
+}
+
+// HashInlineScripts makes [CSPPolicy.Apply] allow each inline script in the
+// content by adding its sha256 hash to script-src.
+func (p *CSPPolicy) HashInlineScripts() *CSPPolicy {
+	p.hashScripts = true
+	return p
+}
+
+// AddScriptHash allows an inline script by adding its sha256 hash to script-src.
+// The script must be passed exactly as it appears between the <script> tags.
+// A new script-src starts with the default-src sources, see
+// [CSPPolicy.Apply].
+func (p *CSPPolicy) AddScriptHash(script string) *CSPPolicy {
+	return p.addScriptSources(CSPHash(script))
+}
+
+// addScriptSources appends sources to script-src. A script-src directive
+// replaces default-src for scripts, so a new one starts with the default-src
+// sources other than 'none'; otherwise allowing a nonce or hash would block
+// the scripts default-src allowed.
+func (p *CSPPolicy) addScriptSources(sources ...string) *CSPPolicy {
+	if !p.hasDirective("script-src") {
+		var inherited []string
+		for _, d := range p.directives {
+			if d.name == "default-src" {
+				inherited = slices.DeleteFunc(slices.Clone(d.sources), func(src string) bool { return src == CSPNone })
+			}
+		}
+		p.Directive("script-src", inherited...)
+	}
+	return p.ScriptSrc(sources...)
+}
+
+// CSPHash returns the 'sha256-...' source expression for an inline script or style.
+func CSPHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
+
+// String returns the serialized policy, e.g. "default-src 'none'; img-src data:".
+func (p *CSPPolicy) String() string {
+	parts := make([]string, 0, len(p.directives))
+	for _, d := range p.directives {
+		if len(d.sources) == 0 {
+			parts = append(parts, d.name)
+			continue
+		}
+		parts = append(parts, d.name+" "+strings.Join(d.sources, " "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// hasDirective reports whether the named directive has been set.
+func (p *CSPPolicy) hasDirective(name string) bool {
+	for _, d := range p.directives {
+		if d.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a deep copy of the policy.
+func (p *CSPPolicy) clone() *CSPPolicy {
+	c := &CSPPolicy{nonce: p.nonce, hashScripts: p.hashScripts}
+	for _, d := range p.directives {
+		c.directives = append(c.directives, cspDirective{name: d.name, sources: slices.Clone(d.sources)})
+	}
+	return c
+}
+
+// Apply returns a copy of content with the policy injected as a
+// <meta http-equiv="Content-Security-Policy"> element at the start of <head>.
+//
+// When a nonce is configured, every inline <script> receives a nonce
+// attribute and 'nonce-...' is added to script-src. Inline <style> elements
+// are nonced as well when a style-src directive is present. When
+// [CSPPolicy.HashInlineScripts] is enabled, the sha256 hash of every inline
+// script is added to script-src. If script-src is not set, it is created
+// with the default-src sources so that adding a nonce or hash does not
+// block scripts default-src allowed. The receiver is not modified.
+//
+// The rest of the document is left byte for byte as it was: the meta
+// element and nonce attributes are spliced into the original markup. The
+// nonce attribute is placed first in the tag, so it takes precedence over
+// an existing one.
+func (p *CSPPolicy) Apply(content *HTMLContent) (*HTMLContent, error) {
+	if content == nil {
+		return nil, errors.New("content is required")
+	}
+	policy := p.clone()
+	nonceStyles := policy.nonce != "" && policy.hasDirective("style-src")
+	if policy.nonce != "" {
+		policy.addScriptSources("'nonce-" + policy.nonce + "'")
+		if nonceStyles {
+			policy.StyleSrc("'nonce-" + policy.nonce + "'")
+		}
+	}
+
+	tokens := tokenizeHTML(content.HTML)
+	for i := range tokens {
+		tok := &tokens[i]
+		if tok.Type != htmlStartTag {
+			continue
+		}
+		switch tok.Data {
+		case "script":
+			if policy.nonce != "" {
+				prependRawAttr(tok, "nonce", policy.nonce)
+			}
+			if _, external := tok.attr("src"); !external && policy.hashScripts {
+				policy.AddScriptHash(inlineBody(tokens, i))
+			}
+		case "style":
+			if nonceStyles {
+				prependRawAttr(tok, "nonce", policy.nonce)
+			}
+		}
+	}
+
+	meta := `<meta http-equiv="Content-Security-Policy" content="` + html.EscapeString(policy.String()) + `">`
+	out := *content
+	out.HTML = injectHead(tokens, meta)
+	return &out, nil
+}
+
+// prependRawAttr inserts an attribute right after the tag name in the raw
+// source of a start tag. Browsers keep the first of duplicate attributes, so
+// it replaces any existing attribute of the same name.
+func prependRawAttr(tok *htmlToken, key, val string) {
+	n := 1 + len(tok.Data) // "<" and the tag name
+	tok.Raw = tok.Raw[:n] + " " + key + `="` + html.EscapeString(val) + `"` + tok.Raw[n:]
+}
+
+// inlineBody returns the raw text content of the raw text element whose
+// start tag is tokens[i].
+func inlineBody(tokens []htmlToken, i int) string {
+	if i+1 < len(tokens) && tokens[i+1].Type == htmlText {
+		return tokens[i+1].Raw
+	}
+	return ""
+}
+
+// injectHead renders the raw source of tokens with markup inserted as
+// early in the document head as possible: after <head>, else after <html>,
+// else after any leading doctype, comments and whitespace.
+func injectHead(tokens []htmlToken, markup string) string {
+	pos := -1
+	for i := range tokens {
+		if tokens[i].Type == htmlStartTag && tokens[i].Data == "head" {
+			pos = i + 1
+			break
+		}
+	}
+	if pos == -1 {
+		for i := range tokens {
+			if tokens[i].Type == htmlStartTag && tokens[i].Data == "html" {
+				pos = i + 1
+				break
+			}
+		}
+	}
+	if pos == -1 {
+		pos = 0
+		for pos < len(tokens) {
+			t := tokens[pos]
+			if t.Type == htmlDoctype || t.Type == htmlComment || (t.Type == htmlText && strings.TrimSpace(t.Raw) == "") {
+				pos++
+				continue
+			}
+			break
+		}
+	}
+
+	var b strings.Builder
+	for i := range tokens {
+		if i == pos {
+			b.WriteString(markup)
+		}
+		b.WriteString(tokens[i].Raw)
+	}
+	if pos >= len(tokens) {
+		b.WriteString(markup)
+	}
+	return b.String()
+}
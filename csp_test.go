@@ -0,0 +1,163 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSPPolicy_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *CSPPolicy
+		want   string
+	}{
+		{
+			name:   "empty",
+			policy: NewCSPPolicy(),
+			want:   "",
+		},
+		{
+			name: "multiple directives",
+			policy: NewCSPPolicy().
+				DefaultSrc(CSPNone).
+				ScriptSrc(CSPSelf, "https://cdn.example.com").
+				ImgSrc(CSPData),
+			want: "default-src 'none'; script-src 'self' https://cdn.example.com; img-src data:",
+		},
+		{
+			name: "duplicate sources are merged",
+			policy: NewCSPPolicy().
+				ConnectSrc("https://api.example.com").
+				ConnectSrc("https://api.example.com", CSPSelf),
+			want: "connect-src https://api.example.com 'self'",
+		},
+		{
+			name:   "directive without sources",
+			policy: NewCSPPolicy().Directive("upgrade-insecure-requests"),
+			want:   "upgrade-insecure-requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.String())
+		})
+	}
+}
+
+func TestCSPHash(t *testing.T) {
+	// Known value: echo -n "alert('hi')" | openssl dgst -sha256 -binary | base64
+	assert.Equal(t, "'sha256-XTqNqFSUlZHAW7f/OGNYSOEzxKhjdAAGMXoid2VEbJk='", CSPHash("alert('hi')"))
+}
+
+func TestNewCSPNonce(t *testing.T) {
+	a, err := NewCSPNonce()
+	require.NoError(t, err)
+	b, err := NewCSPNonce()
+	require.NoError(t, err)
+	assert.Len(t, a, 24)
+	assert.NotEqual(t, a, b)
+}
+
+func TestCSPPolicy_Apply(t *testing.T) {
+	t.Run("injects meta into head", func(t *testing.T) {
+		content := &HTMLContent{HTML: "<html><head><title>T</title></head><body>x</body></html>"}
+		got, err := NewCSPPolicy().DefaultSrc(CSPNone).Apply(content)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`<html><head><meta http-equiv="Content-Security-Policy" content="default-src &#39;none&#39;"><title>T</title></head><body>x</body></html>`,
+			got.HTML)
+		// The input is left untouched.
+		assert.NotContains(t, content.HTML, "Content-Security-Policy")
+	})
+
+	t.Run("fragment without head", func(t *testing.T) {
+		content := &HTMLContent{HTML: "<!DOCTYPE html>\n<div>Hi</div>"}
+		got, err := NewCSPPolicy().DefaultSrc(CSPSelf).Apply(content)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(got.HTML, "<!DOCTYPE html>\n<meta http-equiv="))
+		assert.True(t, strings.HasSuffix(got.HTML, "<div>Hi</div>"))
+	})
+
+	t.Run("nonces inline scripts", func(t *testing.T) {
+		content := &HTMLContent{HTML: `<head></head><script>run()</script><script src="https://cdn.example.com/a.js"></script><style>p{}</style>`}
+		got, err := NewCSPPolicy().DefaultSrc(CSPNone).WithNonce("abc123").Apply(content)
+		require.NoError(t, err)
+		assert.Contains(t, got.HTML, `<script nonce="abc123">run()</script>`)
+		assert.Contains(t, got.HTML, `<script nonce="abc123" src="https://cdn.example.com/a.js">`)
+		assert.Contains(t, got.HTML, "script-src &#39;nonce-abc123&#39;")
+		// No style-src directive, so styles are left alone.
+		assert.Contains(t, got.HTML, "<style>p{}</style>")
+	})
+
+	t.Run("nonces styles when style-src is set", func(t *testing.T) {
+		content := &HTMLContent{HTML: `<style>p{}</style>`}
+		got, err := NewCSPPolicy().StyleSrc(CSPSelf).WithNonce("n1").Apply(content)
+		require.NoError(t, err)
+		assert.Contains(t, got.HTML, `<style nonce="n1">p{}</style>`)
+		assert.Contains(t, got.HTML, "style-src &#39;self&#39; &#39;nonce-n1&#39;")
+	})
+
+	t.Run("hashes inline scripts", func(t *testing.T) {
+		content := &HTMLContent{HTML: `<script>alert('hi')</script><script src="x.js"></script>`}
+		policy := NewCSPPolicy().ScriptSrc(CSPSelf).HashInlineScripts()
+		got, err := policy.Apply(content)
+		require.NoError(t, err)
+		assert.Contains(t, got.HTML, "sha256-XTqNqFSUlZHAW7f/OGNYSOEzxKhjdAAGMXoid2VEbJk=")
+		// Applying does not leak hashes into the reusable policy.
+		assert.Equal(t, "script-src 'self'", policy.String())
+	})
+
+	t.Run("keeps the original markup", func(t *testing.T) {
+		doc := `<HTML><Head><Script Type="module" SRC=a.js async="" data-x=1 data-x=2></Script><STYLE media="all">p{}</STYLE></Head><DIV Class=A hidden="">x</DIV></HTML>`
+		got, err := NewCSPPolicy().StyleSrc(CSPSelf).WithNonce("n1").Apply(&HTMLContent{HTML: doc})
+		require.NoError(t, err)
+		meta := `<meta http-equiv="Content-Security-Policy" content="style-src &#39;self&#39; &#39;nonce-n1&#39;; script-src &#39;nonce-n1&#39;">`
+		assert.Equal(t,
+			`<HTML><Head>`+meta+`<Script nonce="n1" Type="module" SRC=a.js async="" data-x=1 data-x=2></Script><STYLE nonce="n1" media="all">p{}</STYLE></Head><DIV Class=A hidden="">x</DIV></HTML>`,
+			got.HTML)
+	})
+
+	t.Run("existing nonce is overridden", func(t *testing.T) {
+		got, err := NewCSPPolicy().WithNonce("new").Apply(&HTMLContent{HTML: `<script nonce="old">run()</script>`})
+		require.NoError(t, err)
+		assert.Contains(t, got.HTML, `<script nonce="new" nonce="old">run()</script>`)
+	})
+
+	t.Run("script-src inherits default-src", func(t *testing.T) {
+		content := &HTMLContent{HTML: `<script>alert('hi')</script>`}
+		got, err := NewCSPPolicy().DefaultSrc(CSPSelf, "https://cdn.example.com").WithNonce("n1").HashInlineScripts().Apply(content)
+		require.NoError(t, err)
+		assert.Contains(t, got.HTML, "script-src &#39;self&#39; https://cdn.example.com &#39;nonce-n1&#39; &#39;sha256-")
+
+		got, err = NewCSPPolicy().DefaultSrc(CSPNone).HashInlineScripts().Apply(content)
+		require.NoError(t, err)
+		assert.Contains(t, got.HTML, "default-src &#39;none&#39;; script-src &#39;sha256-", "'none' is not inherited")
+
+		got, err = NewCSPPolicy().DefaultSrc(CSPSelf).ScriptSrc("https://js.example.com").WithNonce("n1").Apply(content)
+		require.NoError(t, err)
+		assert.Contains(t, got.HTML, "script-src https://js.example.com &#39;nonce-n1&#39;", "an explicit script-src is kept")
+
+		policy := NewCSPPolicy().DefaultSrc(CSPSelf).AddScriptHash("x")
+		assert.Equal(t, "default-src 'self'; script-src 'self' "+CSPHash("x"), policy.String())
+	})
+
+	t.Run("preserves annotations", func(t *testing.T) {
+		content := &HTMLContent{HTML: "<p>x</p>", Annotations: &Annotations{Audience: []string{"user"}}}
+		got, err := NewCSPPolicy().DefaultSrc(CSPNone).Apply(content)
+		require.NoError(t, err)
+		assert.Equal(t, content.Annotations, got.Annotations)
+	})
+
+	t.Run("nil content", func(t *testing.T) {
+		_, err := NewCSPPolicy().Apply(nil)
+		assert.Error(t, err)
+	})
+}
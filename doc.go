@@ -18,7 +18,9 @@
 //   - [RemoteDOMContent]: Script-based UI using remote DOM rendering
 //
 // Each content type implements the [UIContent] interface and can be serialized
-// to JSON for transmission to clients.
+// to JSON for transmission to clients. [MCPAppContent] serves the same HTML
+// to hosts implementing the MCP Apps extension, and [OpenAIAdapter] converts
+// it to [SkybridgeContent] for ChatGPT's Apps SDK.
 //
 // # Creating UI Resources
 //
@@ -53,6 +53,27 @@ func ExampleRemoteDOMContent() {
 	// Output: {"mimeType":"application/vnd.mcp-ui.remote-dom+javascript; framework=react","text":"React.createElement('div', null, 'Hello from React!');"}
 }
 
+// ExampleNewRemoteDOMContent demonstrates building a Remote DOM script from Go.
+func ExampleNewRemoteDOMContent() {
+	refresh, _ := mcpui.NewToolAction("", "refresh", nil)
+
+	tree := mcpui.NewRemoteElement("ui-button").
+		SetAttribute("label", "Refresh").
+		On("press", refresh)
+
+	content, err := mcpui.NewRemoteDOMContent(mcpui.FrameworkWebComponents, tree)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Print(content.Script)
+	// Output:
+	// const el0 = document.createElement("ui-button");
+	// el0.setAttribute("label", "Refresh");
+	// el0.addEventListener("press", () => { window.parent.postMessage({"type":"tool","payload":{"toolName":"refresh"}}, "*"); });
+	// root.appendChild(el0);
+}
+
 // ExampleUIResource demonstrates creating a UI resource definition.
 func ExampleUIResource() {
 	resource := &mcpui.UIResource{
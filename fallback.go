@@ -0,0 +1,451 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TextFallback returns a plain-text rendering of content for clients that
+// do not display UI resources:
+//
+//   - [HTMLContent] and [MCPAppContent] become Markdown, keeping headings,
+//     paragraphs, lists, tables, links, emphasis and preformatted text.
+//     Scripts, styles and other non-visible elements are dropped.
+//   - [URLContent] becomes its primary URL.
+//   - [RemoteDOMContent] and [BlobContent] are summarized in one line.
+//
+// It returns "" for nil content.
+func TextFallback(content UIContent) string {
+	switch c := content.(type) {
+	case *HTMLContent:
+		return htmlToMarkdown(c.HTML)
+	case *MCPAppContent:
+		return htmlToMarkdown(c.HTML)
+	case *SkybridgeContent:
+		return htmlToMarkdown(c.HTML)
+	case *URLContent:
+		return c.URL
+	case *RemoteDOMContent:
+		if c.Framework != "" {
+			return fmt.Sprintf("[Interactive UI (Remote DOM, %s)]", c.Framework)
+		}
+		return "[Interactive UI (Remote DOM)]"
+	case *BlobContent:
+		mimeType := c.ContentMIMEType
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		kind := "Binary content"
+		if strings.HasPrefix(mimeType, "image/") {
+			kind = "Image"
+		}
+		return fmt.Sprintf("[%s: %s, %s]", kind, mimeType, formatByteSize(len(c.Data)))
+	default:
+		return ""
+	}
+}
+
+// formatByteSize formats n as bytes, KiB or MiB.
+func formatByteSize(n int) string {
+	switch {
+	case n < 1<<10:
+		return strconv.Itoa(n) + " bytes"
+	case n < 1<<20:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	}
+}
+
+// fallbackSkipElements are elements whose content is not visible text.
+// The head is not skipped as a whole because its end tag is optional; its
+// only text is the title.
+var fallbackSkipElements = map[string]bool{
+	"title":    true,
+	"script":   true,
+	"style":    true,
+	"template": true,
+	"noscript": true,
+	"svg":      true,
+	"canvas":   true,
+	"iframe":   true,
+	"object":   true,
+	"select":   true,
+}
+
+// fallbackBlockElements start and end a paragraph.
+var fallbackBlockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true,
+	"footer": true, "main": true, "nav": true, "aside": true, "form": true,
+	"fieldset": true, "figure": true, "figcaption": true, "blockquote": true,
+	"address": true, "details": true, "summary": true, "dl": true, "dt": true,
+	"dd": true, "body": true, "html": true, "caption": true,
+}
+
+// fallbackInlineMarkers are the Markdown markers of inline elements.
+var fallbackInlineMarkers = map[string]string{
+	"strong": "**",
+	"b":      "**",
+	"em":     "*",
+	"i":      "*",
+	"code":   "`",
+	"del":    "~~",
+	"s":      "~~",
+}
+
+// markdownConverter renders an HTML token stream as Markdown.
+type markdownConverter struct {
+	// out is a stack of buffers. Inline elements, links and table cells
+	// capture their text in a buffer of their own.
+	out []*strings.Builder
+	// captures records the element owning each buffer above the first.
+	captures []mdCapture
+
+	skip     string // name of the element being skipped, if any
+	skipping int    // nesting depth of skip
+	pre      int
+	lists    []mdList
+	tables   []*mdTable
+}
+
+type mdCapture struct {
+	tag  string
+	href string
+}
+
+type mdList struct {
+	ordered bool
+	n       int
+}
+
+type mdTable struct {
+	rows [][]string
+}
+
+func htmlToMarkdown(doc string) string {
+	c := &markdownConverter{out: []*strings.Builder{new(strings.Builder)}}
+	for _, tok := range tokenizeHTML(doc) {
+		c.token(tok)
+	}
+	// Close elements left open by the document.
+	for len(c.captures) > 0 {
+		c.endCapture()
+	}
+	for len(c.tables) > 0 {
+		c.endTable()
+	}
+	return cleanMarkdown(c.out[0].String())
+}
+
+func (c *markdownConverter) buf() *strings.Builder {
+	return c.out[len(c.out)-1]
+}
+
+func (c *markdownConverter) token(tok htmlToken) {
+	if c.skipping > 0 {
+		switch {
+		case tok.Type == htmlStartTag && tok.Data == c.skip:
+			c.skipping++
+		case tok.Type == htmlEndTag && tok.Data == c.skip:
+			c.skipping--
+		}
+		return
+	}
+	switch tok.Type {
+	case htmlText:
+		c.text(html.UnescapeString(tok.Data))
+	case htmlStartTag, htmlSelfClosingTag:
+		if fallbackSkipElements[tok.Data] {
+			if tok.Type == htmlStartTag {
+				c.skip, c.skipping = tok.Data, 1
+			}
+			return
+		}
+		c.start(&tok)
+	case htmlEndTag:
+		c.end(tok.Data)
+	}
+}
+
+func (c *markdownConverter) start(tok *htmlToken) {
+	name := tok.Data
+	switch {
+	case len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6':
+		c.block()
+		c.write(strings.Repeat("#", int(name[1]-'0')) + " ")
+	case name == "br":
+		c.newline()
+	case name == "hr":
+		c.block()
+		c.write("---")
+		c.block()
+	case name == "pre":
+		c.block()
+		c.write("```\n")
+		c.pre++
+	case name == "ul" || name == "ol":
+		c.newline()
+		c.lists = append(c.lists, mdList{ordered: name == "ol"})
+	case name == "li":
+		c.listItem()
+	case name == "table":
+		c.block()
+		c.tables = append(c.tables, &mdTable{})
+	case name == "tr":
+		if t := c.table(); t != nil {
+			t.rows = append(t.rows, nil)
+		}
+	case name == "td" || name == "th":
+		if t := c.table(); t != nil {
+			if len(t.rows) == 0 {
+				t.rows = append(t.rows, nil)
+			}
+			c.beginCapture(mdCapture{tag: name})
+		}
+	case name == "a":
+		href, _ := tok.attr("href")
+		c.beginCapture(mdCapture{tag: name, href: href})
+	case name == "img":
+		if alt, _ := tok.attr("alt"); alt != "" {
+			c.text(" " + alt + " ")
+		}
+	case name == "input":
+		if v, _ := tok.attr("value"); v != "" {
+			c.text(v)
+		}
+	case fallbackInlineMarkers[name] != "" && c.pre == 0:
+		c.beginCapture(mdCapture{tag: name})
+	case fallbackBlockElements[name]:
+		c.block()
+	}
+}
+
+func (c *markdownConverter) end(name string) {
+	switch {
+	case len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6':
+		c.block()
+	case name == "pre":
+		if c.pre > 0 {
+			c.pre--
+			c.newline()
+			c.write("```")
+			c.block()
+		}
+	case name == "ul" || name == "ol":
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		if len(c.lists) == 0 {
+			c.block()
+		}
+	case name == "table":
+		c.endTable()
+	case name == "td" || name == "th" || name == "a" || fallbackInlineMarkers[name] != "":
+		// Close captures up to the matching element, if it is open.
+		for i := len(c.captures) - 1; i >= 0; i-- {
+			if c.captures[i].tag == name {
+				for len(c.captures) > i {
+					c.endCapture()
+				}
+				break
+			}
+		}
+	case fallbackBlockElements[name]:
+		c.block()
+	}
+}
+
+func (c *markdownConverter) text(s string) {
+	if c.pre > 0 {
+		if strings.HasSuffix(c.buf().String(), "```\n") {
+			// A newline right after <pre> is not part of the content.
+			s = strings.TrimPrefix(s, "\n")
+		}
+		c.write(s)
+		return
+	}
+	s = collapseSpace(s)
+	b := c.buf()
+	if strings.HasPrefix(s, " ") && (b.Len() == 0 || endsWithSpace(b.String())) {
+		s = s[1:]
+	}
+	c.write(s)
+}
+
+func (c *markdownConverter) write(s string) {
+	c.buf().WriteString(s)
+}
+
+func endsWithSpace(s string) bool {
+	return s != "" && isHTMLSpace(s[len(s)-1])
+}
+
+// block starts a new paragraph. Inside captured text, such as a table
+// cell, it only separates words.
+func (c *markdownConverter) block() {
+	if len(c.out) > 1 {
+		c.text(" ")
+		return
+	}
+	b := c.buf()
+	s := b.String()
+	if s == "" || strings.HasSuffix(s, "\n\n") {
+		return
+	}
+	if strings.HasSuffix(s, "\n") {
+		b.WriteByte('\n')
+		return
+	}
+	b.WriteString("\n\n")
+}
+
+// newline starts a new line.
+func (c *markdownConverter) newline() {
+	if len(c.out) > 1 {
+		c.text(" ")
+		return
+	}
+	b := c.buf()
+	if s := b.String(); s != "" && !strings.HasSuffix(s, "\n") {
+		b.WriteByte('\n')
+	}
+}
+
+func (c *markdownConverter) listItem() {
+	if len(c.lists) == 0 {
+		c.lists = append(c.lists, mdList{})
+	}
+	l := &c.lists[len(c.lists)-1]
+	l.n++
+	c.newline()
+	c.write(strings.Repeat("  ", len(c.lists)-1))
+	if l.ordered {
+		c.write(strconv.Itoa(l.n) + ". ")
+	} else {
+		c.write("- ")
+	}
+}
+
+func (c *markdownConverter) beginCapture(capture mdCapture) {
+	c.captures = append(c.captures, capture)
+	c.out = append(c.out, new(strings.Builder))
+}
+
+// endCapture closes the innermost capture and writes its text to the
+// enclosing buffer.
+func (c *markdownConverter) endCapture() {
+	capture := c.captures[len(c.captures)-1]
+	text := strings.TrimSpace(c.buf().String())
+	c.captures = c.captures[:len(c.captures)-1]
+	c.out = c.out[:len(c.out)-1]
+
+	switch capture.tag {
+	case "td", "th":
+		if t := c.table(); t != nil && len(t.rows) > 0 {
+			row := &t.rows[len(t.rows)-1]
+			*row = append(*row, strings.ReplaceAll(text, "|", `\|`))
+			return
+		}
+		c.text(" " + text + " ")
+	case "a":
+		c.text(markdownLink(text, capture.href))
+	default:
+		if text != "" {
+			marker := fallbackInlineMarkers[capture.tag]
+			c.text(marker + text + marker)
+		}
+	}
+}
+
+// markdownLink formats a link, dropping targets that are not useful
+// outside the UI such as fragments and javascript: URLs.
+func markdownLink(text, href string) string {
+	href = strings.TrimSpace(href)
+	lower := strings.ToLower(href)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "mailto:") {
+		return text
+	}
+	if text == "" || text == href {
+		return href
+	}
+	return "[" + text + "](" + href + ")"
+}
+
+func (c *markdownConverter) table() *mdTable {
+	if len(c.tables) == 0 {
+		return nil
+	}
+	return c.tables[len(c.tables)-1]
+}
+
+// endTable writes the innermost table. Nested tables are flattened into
+// the enclosing cell.
+func (c *markdownConverter) endTable() {
+	t := c.table()
+	if t == nil {
+		return
+	}
+	for len(c.captures) > 0 && (c.captures[len(c.captures)-1].tag == "td" || c.captures[len(c.captures)-1].tag == "th") {
+		c.endCapture()
+	}
+	c.tables = c.tables[:len(c.tables)-1]
+
+	var rows [][]string
+	width := 0
+	for _, row := range t.rows {
+		if len(row) > 0 {
+			rows = append(rows, row)
+			width = max(width, len(row))
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+	if len(c.tables) > 0 {
+		for _, row := range rows {
+			c.text(" " + strings.Join(row, " ") + " ")
+		}
+		return
+	}
+
+	var b strings.Builder
+	writeRow := func(row []string) {
+		b.WriteString("|")
+		for i := range width {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			b.WriteString(" " + cell + " |")
+		}
+		b.WriteString("\n")
+	}
+	// Markdown tables need a header row; use the first row.
+	writeRow(rows[0])
+	b.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	c.block()
+	c.write(b.String())
+	c.block()
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// cleanMarkdown trims trailing spaces and collapses blank lines.
+func cleanMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	s = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(s, "\n\n"))
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextFallback_HTML(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "headings and paragraphs",
+			html: "<h1>Server  Status</h1>\n<p>All systems\n  operational.</p><h3>Details</h3><div>One</div><div>Two</div>",
+			want: "# Server Status\n\nAll systems operational.\n\n### Details\n\nOne\n\nTwo",
+		},
+		{
+			name: "inline formatting",
+			html: "<p>Now <b>bold</b>, <em> leaning </em> and <code>x := 1</code>.</p>",
+			want: "Now **bold**, *leaning* and `x := 1`.",
+		},
+		{
+			name: "links",
+			html: `<p><a href="https://example.com/docs">Docs</a>, <a href="https://example.com">https://example.com</a>, <a href="#top">Top</a>, <a href="javascript:run()">Run</a>, <a href="mailto:ops@example.com"></a></p>`,
+			want: "[Docs](https://example.com/docs), https://example.com, Top, Run, mailto:ops@example.com",
+		},
+		{
+			name: "nested lists",
+			html: "<ul>\n<li>API</li>\n<li>Workers\n<ol><li>one</li><li>two</li></ol></li>\n</ul><p>After</p>",
+			want: "- API\n- Workers\n  1. one\n  2. two\n\nAfter",
+		},
+		{
+			name: "table",
+			html: `<table><thead><tr><th>Name</th><th>State</th></tr></thead>
+				<tbody><tr><td>db</td><td>ok | fine</td></tr><tr><td><a href="https://example.com/c">cache</a></td></tr></tbody></table>`,
+			want: "| Name | State |\n| --- | --- |\n| db | ok \\| fine |\n| [cache](https://example.com/c) |  |",
+		},
+		{
+			name: "preformatted",
+			html: "<pre><code>go run .\n  done</code></pre>",
+			want: "```\ngo run .\n  done\n```",
+		},
+		{
+			name: "hidden content dropped",
+			html: `<html><head><title>T</title><style>p{}</style></head><body><script>alert("<p>x</p>")</script><svg><text>chart</text></svg><p>Shown</p><template><p>no</p></template></body></html>`,
+			want: "Shown",
+		},
+		{
+			name: "head without end tag",
+			html: `<html><head><meta charset=utf-8><title>T</title><body><h1>Hello</h1><p>World</p></body></html>`,
+			want: "# Hello\n\nWorld",
+		},
+		{
+			name: "line breaks, rules, images and entities",
+			html: `Tom &amp; Jerry<br>Line two<hr><img src="logo.png" alt="Logo">`,
+			want: "Tom & Jerry\nLine two\n\n---\n\nLogo",
+		},
+		{
+			name: "unclosed elements",
+			html: "<p>Open <b>bold<table><tr><td>cell",
+			want: "Open **bold**\n\n| cell |\n| --- |",
+		},
+		{
+			name: "empty",
+			html: "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TextFallback(&HTMLContent{HTML: tt.html}))
+		})
+	}
+}
+
+func TestTextFallback_OtherContent(t *testing.T) {
+	assert.Equal(t, "https://example.com/app", TextFallback(&URLContent{
+		URL:       "https://example.com/app",
+		Fallbacks: []string{"https://backup.example.com"},
+	}))
+	assert.Equal(t, "[Interactive UI (Remote DOM, react)]", TextFallback(&RemoteDOMContent{Script: "x", Framework: FrameworkReact}))
+	assert.Equal(t, "[Interactive UI (Remote DOM)]", TextFallback(&RemoteDOMContent{Script: "x"}))
+	assert.Equal(t, "[Image: image/png, 2.0 KiB]", TextFallback(&BlobContent{Data: make([]byte, 2048), ContentMIMEType: "image/png"}))
+	assert.Equal(t, "[Binary content: application/octet-stream, 3 bytes]", TextFallback(&BlobContent{Data: []byte("abc")}))
+	assert.Equal(t, "[Binary content: font/woff2, 1.5 MiB]", TextFallback(&BlobContent{Data: make([]byte, 3<<19), ContentMIMEType: "font/woff2"}))
+	assert.Equal(t, "", TextFallback(nil))
+}
+
+func TestTextFallback_LongDocument(t *testing.T) {
+	doc := strings.Repeat("<div><p>Row <b>x</b></p></div>", 1000)
+	text := TextFallback(&HTMLContent{HTML: doc})
+	assert.Equal(t, 1000, strings.Count(text, "Row **x**"))
+	assert.NotContains(t, text, "\n\n\n")
+}
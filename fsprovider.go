@@ -0,0 +1,328 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+// File name suffixes recognized by [FSProvider].
+const (
+	// HTMLFileSuffix marks files served as [HTMLContent].
+	HTMLFileSuffix = ".html"
+	// RemoteDOMFileSuffix marks files served as [RemoteDOMContent].
+	RemoteDOMFileSuffix = ".remote.js"
+	// MetadataFileSuffix marks sidecar metadata files, see [ResourceMetadata].
+	MetadataFileSuffix = ".meta.json"
+)
+
+// ResourceMetadata is the content of an optional sidecar file describing a
+// file-backed resource. The sidecar of an HTML or Remote DOM file replaces
+// its type suffix, and the sidecar of any other file extends its full name,
+// so "dashboard/main.html" is described by "dashboard/main.meta.json" and
+// "logo.png" by "logo.png.meta.json":
+//
+//	{
+//	  "title": "Main Dashboard",
+//	  "description": "Live service status",
+//	  "annotations": {"audience": ["user"], "priority": 0.8}
+//	}
+type ResourceMetadata struct {
+	// Name overrides the resource name, which defaults to the file path
+	// without its type suffix.
+	Name string `json:"name,omitempty"`
+	// Title is the human-readable display name.
+	Title string `json:"title,omitempty"`
+	// Description explains what the resource represents.
+	Description string `json:"description,omitempty"`
+	// MIMEType overrides the MIME type inferred from the file extension.
+	// It only applies to binary files.
+	MIMEType string `json:"mimeType,omitempty"`
+	// Framework selects the Remote DOM framework for .remote.js files.
+	Framework Framework `json:"framework,omitempty"`
+	// Annotations are set on both the resource and its contents.
+	Annotations *Annotations `json:"annotations,omitempty"`
+}
+
+// FSProvider serves the files of an [fs.FS] as UI resources.
+//
+// Each file maps to a URI under the provider's base URI. The type is
+// inferred from the file name:
+//
+//   - name.html becomes [HTMLContent] at base + "name"
+//   - name.remote.js becomes [RemoteDOMContent] at base + "name"
+//   - any other file becomes [BlobContent] at base + its full name, with
+//     the MIME type derived from the extension
+//
+// Sidecar files ending in .meta.json hold [ResourceMetadata] and are not
+// served. Files and directories whose names start with "." are ignored.
+//
+// Example:
+//
+//	//go:embed ui
+//	var uiFS embed.FS
+//
+//	sub, _ := fs.Sub(uiFS, "ui")
+//	provider, err := mcpui.NewFSProvider(sub, "ui://app/")
+//	// ui/dashboard.html is now ui://app/dashboard
+type FSProvider struct {
+	fsys fs.FS
+	base string
+}
+
+// NewFSProvider creates a provider serving fsys under baseURI, which must
+// start with "ui://". A trailing slash is added if missing, unless baseURI is
+// exactly "ui://".
+func NewFSProvider(fsys fs.FS, baseURI string) (*FSProvider, error) {
+	if fsys == nil {
+		return nil, errors.New("file system is required")
+	}
+	if !strings.HasPrefix(baseURI, URIScheme) {
+		return nil, errors.New("base URI must start with " + URIScheme)
+	}
+	if baseURI != URIScheme && !strings.HasSuffix(baseURI, "/") {
+		baseURI += "/"
+	}
+	return &FSProvider{fsys: fsys, base: baseURI}, nil
+}
+
+// BaseURI returns the URI prefix of the provider's resources.
+func (p *FSProvider) BaseURI() string { return p.base }
+
+// fsEntry is a servable file and the resource path it maps to.
+type fsEntry struct {
+	file string // path in the file system
+	name string // path relative to the base URI
+	kind fsKind
+}
+
+type fsKind int
+
+const (
+	fsBlob fsKind = iota
+	fsHTML
+	fsRemoteDOM
+)
+
+// classifyFile returns the entry for a file system path, or false if the
+// file is not served.
+func classifyFile(file string) (fsEntry, bool) {
+	for _, elem := range strings.Split(file, "/") {
+		if strings.HasPrefix(elem, ".") {
+			return fsEntry{}, false
+		}
+	}
+	switch {
+	case strings.HasSuffix(file, MetadataFileSuffix):
+		return fsEntry{}, false
+	case strings.HasSuffix(file, RemoteDOMFileSuffix):
+		return fsEntry{file: file, name: strings.TrimSuffix(file, RemoteDOMFileSuffix), kind: fsRemoteDOM}, true
+	case strings.HasSuffix(file, HTMLFileSuffix):
+		return fsEntry{file: file, name: strings.TrimSuffix(file, HTMLFileSuffix), kind: fsHTML}, true
+	}
+	return fsEntry{file: file, name: file, kind: fsBlob}, true
+}
+
+// entries walks the file system and returns the servable files sorted by URI.
+func (p *FSProvider) entries() ([]fsEntry, error) {
+	var entries []fsEntry
+	seen := make(map[string]string)
+	err := fs.WalkDir(p.fsys, ".", func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if file != "." && strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		e, ok := classifyFile(file)
+		if !ok {
+			return nil
+		}
+		if other, dup := seen[e.name]; dup {
+			return fmt.Errorf("files %s and %s map to the same URI %s", other, file, p.base+e.name)
+		}
+		seen[e.name] = file
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(entries, func(a, b fsEntry) int { return strings.Compare(a.name, b.name) })
+	return entries, nil
+}
+
+// lookup finds the file backing a URI.
+func (p *FSProvider) lookup(uri string) (fsEntry, error) {
+	notFound := fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+	name, ok := strings.CutPrefix(uri, p.base)
+	if !ok || name == "" || !fs.ValidPath(name) {
+		return fsEntry{}, notFound
+	}
+	for _, file := range []string{name + HTMLFileSuffix, name + RemoteDOMFileSuffix, name} {
+		e, ok := classifyFile(file)
+		if !ok || e.name != name {
+			continue
+		}
+		info, err := fs.Stat(p.fsys, file)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return fsEntry{}, err
+		}
+		if info.IsDir() {
+			continue
+		}
+		return e, nil
+	}
+	return fsEntry{}, notFound
+}
+
+// metadata reads the sidecar file for an entry, if any.
+func (p *FSProvider) metadata(e fsEntry) (*ResourceMetadata, error) {
+	file := sidecarPath(e)
+	data, err := fs.ReadFile(p.fsys, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &ResourceMetadata{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var meta ResourceMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("invalid metadata %s: %w", file, err)
+	}
+	return &meta, nil
+}
+
+// sidecarPath returns the metadata file path for an entry. Entries share a
+// sidecar only if they map to the same URI, which entries rejects.
+func sidecarPath(e fsEntry) string {
+	return e.name + MetadataFileSuffix
+}
+
+// resource describes an entry as a UIResource.
+func (p *FSProvider) resource(e fsEntry, meta *ResourceMetadata) *UIResource {
+	r := &UIResource{
+		URI:         p.base + e.name,
+		Name:        e.name,
+		Title:       meta.Title,
+		Description: meta.Description,
+		Annotations: meta.Annotations,
+	}
+	if meta.Name != "" {
+		r.Name = meta.Name
+	}
+	switch e.kind {
+	case fsHTML:
+		r.MIMEType = MIMETypeHTML
+	case fsRemoteDOM:
+		r.MIMEType = (&RemoteDOMContent{Framework: meta.Framework}).mimeType()
+	default:
+		r.MIMEType = blobMIMEType(e, meta)
+	}
+	return r
+}
+
+func blobMIMEType(e fsEntry, meta *ResourceMetadata) string {
+	if meta.MIMEType != "" {
+		return meta.MIMEType
+	}
+	return mimeTypeForPath(e.file)
+}
+
+// content reads the file backing an entry.
+func (p *FSProvider) content(e fsEntry, meta *ResourceMetadata) (UIContent, error) {
+	data, err := fs.ReadFile(p.fsys, e.file)
+	if err != nil {
+		return nil, err
+	}
+	switch e.kind {
+	case fsHTML:
+		return &HTMLContent{HTML: string(data), Annotations: meta.Annotations}, nil
+	case fsRemoteDOM:
+		c := &RemoteDOMContent{Script: string(data), Framework: meta.Framework, Annotations: meta.Annotations}
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.file, err)
+		}
+		return c, nil
+	}
+	return &BlobContent{Data: data, ContentMIMEType: blobMIMEType(e, meta), Annotations: meta.Annotations}, nil
+}
+
+// Resources returns a resource for every servable file, sorted by URI.
+func (p *FSProvider) Resources() ([]*UIResource, error) {
+	entries, err := p.entries()
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*UIResource, 0, len(entries))
+	for _, e := range entries {
+		meta, err := p.metadata(e)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, p.resource(e, meta))
+	}
+	return resources, nil
+}
+
+// List returns all resources in a single page. The provider never issues
+// cursors, so a non-empty cursor returns an error wrapping
+// [ErrInvalidCursor].
+func (p *FSProvider) List(ctx context.Context, cursor string) (*ListUIResourcesResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if cursor != "" {
+		return nil, ErrInvalidCursor
+	}
+	resources, err := p.Resources()
+	if err != nil {
+		return nil, err
+	}
+	return &ListUIResourcesResult{Resources: resources}, nil
+}
+
+// Content returns the content of the resource at uri. The error wraps
+// [ErrResourceNotFound] if no file maps to uri.
+func (p *FSProvider) Content(uri string) (UIContent, error) {
+	e, err := p.lookup(uri)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := p.metadata(e)
+	if err != nil {
+		return nil, err
+	}
+	return p.content(e, meta)
+}
+
+// Read returns the contents of the resource at uri. The error wraps
+// [ErrResourceNotFound] if no file maps to uri.
+func (p *FSProvider) Read(ctx context.Context, uri string) (*ReadUIResourceResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content, err := p.Content(uri)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := NewUIResourceContents(uri, content)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadUIResourceResult{Contents: []*UIResourceContents{rc}}, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func providerFS() fstest.MapFS {
+	return fstest.MapFS{
+		"dashboard/main.html":      {Data: []byte("<h1>Main</h1>")},
+		"dashboard/main.meta.json": {Data: []byte(`{"title":"Main Dashboard","description":"Status","annotations":{"audience":["user"]}}`)},
+		"widgets/chart.remote.js":  {Data: []byte(`root.appendChild(document.createElement("ui-text"));`)},
+		"widgets/chart.meta.json":  {Data: []byte(`{"name":"chart","framework":"webcomponents"}`)},
+		"img/logo.png":             {Data: []byte{0x89, 'P', 'N', 'G'}},
+		".hidden/secret.html":      {Data: []byte("nope")},
+		"img/.DS_Store":            {Data: []byte{0}},
+	}
+}
+
+func TestFSProvider_List(t *testing.T) {
+	p, err := NewFSProvider(providerFS(), "ui://app")
+	require.NoError(t, err)
+	assert.Equal(t, "ui://app/", p.BaseURI())
+
+	result, err := p.List(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, result.Resources, 3)
+	assert.Empty(t, result.NextCursor)
+
+	_, err = p.List(context.Background(), "next")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	main := result.Resources[0]
+	assert.Equal(t, "ui://app/dashboard/main", main.URI)
+	assert.Equal(t, "dashboard/main", main.Name)
+	assert.Equal(t, "Main Dashboard", main.Title)
+	assert.Equal(t, "Status", main.Description)
+	assert.Equal(t, MIMETypeHTML, main.MIMEType)
+	require.NotNil(t, main.Annotations)
+	assert.Equal(t, []string{"user"}, main.Annotations.Audience)
+
+	logo := result.Resources[1]
+	assert.Equal(t, "ui://app/img/logo.png", logo.URI)
+	assert.Equal(t, "image/png", logo.MIMEType)
+
+	chart := result.Resources[2]
+	assert.Equal(t, "ui://app/widgets/chart", chart.URI)
+	assert.Equal(t, "chart", chart.Name)
+	assert.Equal(t, "application/vnd.mcp-ui.remote-dom+javascript; framework=webcomponents", chart.MIMEType)
+
+	for _, r := range result.Resources {
+		assert.NoError(t, r.Validate())
+	}
+}
+
+func TestFSProvider_Read(t *testing.T) {
+	p, err := NewFSProvider(providerFS(), "ui://app/")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	result, err := p.Read(ctx, "ui://app/dashboard/main")
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "ui://app/dashboard/main", result.Contents[0].URI)
+	assert.Equal(t, MIMETypeHTML, result.Contents[0].MIMEType)
+	assert.Equal(t, "<h1>Main</h1>", result.Contents[0].Text)
+	assert.NotNil(t, result.Contents[0].Annotations)
+
+	content, err := p.Content("ui://app/widgets/chart")
+	require.NoError(t, err)
+	remote, ok := content.(*RemoteDOMContent)
+	require.True(t, ok)
+	assert.Equal(t, FrameworkWebComponents, remote.Framework)
+
+	result, err = p.Read(ctx, "ui://app/img/logo.png")
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", result.Contents[0].MIMEType)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, result.Contents[0].Blob)
+}
+
+func TestFSProvider_BlobSidecars(t *testing.T) {
+	fsys := fstest.MapFS{
+		"logo.png":           {Data: []byte("png")},
+		"logo.png.meta.json": {Data: []byte(`{"title":"PNG logo"}`)},
+		"logo.svg":           {Data: []byte("<svg/>")},
+		"logo.svg.meta.json": {Data: []byte(`{"title":"SVG logo","mimeType":"image/svg+xml"}`)},
+		"logo.meta.json":     {Data: []byte(`{"title":"ignored"}`)},
+	}
+	p, err := NewFSProvider(fsys, "ui://app/")
+	require.NoError(t, err)
+	resources, err := p.Resources()
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, "PNG logo", resources[0].Title)
+	assert.Equal(t, "SVG logo", resources[1].Title)
+	assert.Equal(t, "image/svg+xml", resources[1].MIMEType)
+}
+
+func TestFSProvider_NotFound(t *testing.T) {
+	p, err := NewFSProvider(providerFS(), "ui://app/")
+	require.NoError(t, err)
+	for _, uri := range []string{
+		"ui://app/missing",
+		"ui://other/dashboard/main",
+		"ui://app/dashboard/main.html",
+		"ui://app/dashboard/main.meta.json",
+		"ui://app/.hidden/secret",
+		"ui://app/../dashboard/main",
+		"ui://app/dashboard",
+	} {
+		_, err := p.Read(context.Background(), uri)
+		assert.ErrorIs(t, err, ErrResourceNotFound, uri)
+	}
+}
+
+func TestFSProvider_Errors(t *testing.T) {
+	_, err := NewFSProvider(nil, "ui://app/")
+	assert.Error(t, err)
+	_, err = NewFSProvider(providerFS(), "https://app/")
+	assert.Error(t, err)
+
+	dup := fstest.MapFS{
+		"page.html":      {Data: []byte("a")},
+		"page.remote.js": {Data: []byte("b")},
+	}
+	p, err := NewFSProvider(dup, URIScheme)
+	require.NoError(t, err)
+	_, err = p.Resources()
+	assert.ErrorContains(t, err, "same URI ui://page")
+
+	bad := fstest.MapFS{
+		"page.html":      {Data: []byte("a")},
+		"page.meta.json": {Data: []byte("{")},
+	}
+	p, err = NewFSProvider(bad, URIScheme)
+	require.NoError(t, err)
+	_, err = p.Read(context.Background(), "ui://page")
+	assert.ErrorContains(t, err, "invalid metadata")
+}
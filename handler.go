@@ -6,10 +6,18 @@ package mcpui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 )
 
+// ErrNoHandler is returned by [Router.Dispatch] when no handler matches an action.
+var ErrNoHandler = errors.New("no handler")
+
+// ErrInvalidPayload is returned by the Wrap*Handler helpers when an
+// action's payload cannot be decoded.
+var ErrInvalidPayload = errors.New("invalid payload")
+
 // UIActionHandler handles UI actions from embedded resources.
 // This follows the pattern of mcp.ResourceHandler and mcp.ToolHandler.
 type UIActionHandler func(context.Context, *UIActionRequest) (*UIActionResult, error)
@@ -109,7 +117,11 @@ func (r *Router) Dispatch(ctx context.Context, req *UIActionRequest) (*UIActionR
 		return r.defaultHandler(ctx, req)
 	}
 
-	return nil, fmt.Errorf("no handler for action type %q from resource %q", req.Action.Type, req.ResourceURI)
+	actionType := ""
+	if req.Action != nil {
+		actionType = req.Action.Type
+	}
+	return nil, fmt.Errorf("%w for action type %q from resource %q", ErrNoHandler, actionType, req.ResourceURI)
 }
 
 // Handle implements the UIActionHandler interface, making Router itself a handler.
@@ -129,7 +141,7 @@ func WrapToolHandler(handler ToolHandler) UIActionHandler {
 		}
 		payload, err := req.Action.ToolPayload()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
 		}
 		result, err := handler(ctx, payload.ToolName, payload.Params)
 		if err != nil {
@@ -151,7 +163,7 @@ func WrapIntentHandler(handler IntentHandler) UIActionHandler {
 		}
 		payload, err := req.Action.IntentPayload()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
 		}
 		result, err := handler(ctx, payload.Intent, payload.Params)
 		if err != nil {
@@ -173,7 +185,7 @@ func WrapPromptHandler(handler PromptHandler) UIActionHandler {
 		}
 		payload, err := req.Action.PromptPayload()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
 		}
 		result, err := handler(ctx, payload.Prompt)
 		if err != nil {
@@ -195,7 +207,7 @@ func WrapNotifyHandler(handler NotifyHandler) UIActionHandler {
 		}
 		payload, err := req.Action.NotifyPayload()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
 		}
 		if err := handler(ctx, payload.Message, payload.Level); err != nil {
 			return &UIActionResult{Error: err}, nil
@@ -216,7 +228,7 @@ func WrapLinkHandler(handler LinkHandler) UIActionHandler {
 		}
 		payload, err := req.Action.LinkPayload()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
 		}
 		if err := handler(ctx, payload.URL); err != nil {
 			return &UIActionResult{Error: err}, nil
@@ -237,7 +249,7 @@ func WrapUISizeHandler(handler UISizeHandler) UIActionHandler {
 		}
 		payload, err := req.Action.UISizePayload()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
 		}
 		if err := handler(ctx, payload.Height, payload.Width); err != nil {
 			return &UIActionResult{Error: err}, nil
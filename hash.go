@@ -0,0 +1,244 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrNotModified is returned by [ReadIfModified] when the client's ETag
+// matches the current contents.
+var ErrNotModified = errors.New("not modified")
+
+// Hash returns the hex-encoded SHA-256 digest of the contents: the MIME
+// type, the text or blob payload, the annotations and the metadata. The URI
+// is not included, so identical contents served under different URIs share
+// a hash.
+func (r *UIResourceContents) Hash() string {
+	h := sha256.New()
+	h.Write([]byte(r.MIMEType))
+	h.Write([]byte{0})
+	if r.Blob != nil {
+		h.Write([]byte{'b'})
+		h.Write(r.Blob)
+	} else {
+		h.Write([]byte{'t'})
+		h.Write([]byte(r.Text))
+	}
+	h.Write([]byte{0})
+	if r.Annotations != nil {
+		data, _ := json.Marshal(r.Annotations) // Annotations always marshals
+		h.Write(data)
+	}
+	if r.Meta != nil {
+		// Map keys are marshaled in sorted order, so equal metadata hashes
+		// equally. Unmarshalable metadata is left out.
+		if data, err := json.Marshal(r.Meta); err == nil {
+			h.Write([]byte{0})
+			h.Write(data)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ETag returns a strong HTTP entity tag for the contents, derived from
+// [UIResourceContents.Hash].
+func (r *UIResourceContents) ETag() string {
+	return `"` + r.Hash() + `"`
+}
+
+// ETag returns an entity tag covering all contents of the result in order.
+// A result with a single content has the same ETag as that content.
+func (r *ReadUIResourceResult) ETag() string {
+	if len(r.Contents) == 1 {
+		return r.Contents[0].ETag()
+	}
+	h := sha256.New()
+	for _, c := range r.Contents {
+		h.Write([]byte(c.Hash()))
+		h.Write([]byte{0})
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// ResourceReader reads UI resources. It is implemented by
+// [ResourceRegistry], [FSProvider] and [ResourceWatcher].
+type ResourceReader interface {
+	Read(ctx context.Context, uri string) (*ReadUIResourceResult, error)
+}
+
+// ReadIfModified reads uri and compares the result's ETag with ifNoneMatch,
+// which holds the ETags the client already has in HTTP If-None-Match syntax
+// (a comma-separated list, or "*"). If one matches, it returns the current
+// ETag and [ErrNotModified] instead of the contents.
+//
+// The resource is still read, since dynamic resources can only be compared
+// after rendering; the saving is in not transferring unchanged contents.
+//
+// Example:
+//
+//	result, etag, err := mcpui.ReadIfModified(ctx, registry, uri, r.Header.Get("If-None-Match"))
+//	if errors.Is(err, mcpui.ErrNotModified) {
+//		w.Header().Set("ETag", etag)
+//		w.WriteHeader(http.StatusNotModified)
+//		return
+//	}
+func ReadIfModified(ctx context.Context, reader ResourceReader, uri, ifNoneMatch string) (*ReadUIResourceResult, string, error) {
+	result, err := reader.Read(ctx, uri)
+	if err != nil {
+		return nil, "", err
+	}
+	etag := result.ETag()
+	if etagMatches(ifNoneMatch, etag) {
+		return nil, etag, ErrNotModified
+	}
+	return result, etag, nil
+}
+
+// etagMatches implements the weak comparison of If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	ifNoneMatch = strings.TrimSpace(ifNoneMatch)
+	if ifNoneMatch == "" {
+		return false
+	}
+	if ifNoneMatch == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentStore is a content-addressed store for resource contents. Contents
+// are keyed by [UIResourceContents.Hash], so identical payloads are stored
+// once however many URIs serve them. Entries are reference counted. It is
+// safe for concurrent use.
+//
+// Example:
+//
+//	store := mcpui.NewContentStore()
+//	hash := store.Put(contents)
+//	...
+//	rc, ok := store.Get(hash, "ui://dashboard/main")
+type ContentStore struct {
+	mu      sync.RWMutex
+	entries map[string]*storeEntry
+}
+
+type storeEntry struct {
+	contents UIResourceContents // URI is empty
+	refs     int
+}
+
+// NewContentStore creates an empty store.
+func NewContentStore() *ContentStore {
+	return &ContentStore{entries: make(map[string]*storeEntry)}
+}
+
+// Put stores the contents, or takes another reference to identical stored
+// contents, and returns their hash.
+func (s *ContentStore) Put(rc *UIResourceContents) string {
+	hash := rc.Hash()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[hash]; ok {
+		e.refs++
+		return hash
+	}
+	e := &storeEntry{contents: *rc, refs: 1}
+	e.contents.URI = ""
+	e.contents.Blob = slices.Clone(rc.Blob)
+	e.contents.cloneMetadata()
+	s.entries[hash] = e
+	return hash
+}
+
+// Get returns a copy of the stored contents with the given hash, with its
+// URI set to uri. The Blob slice is shared with the store and must not be
+// modified; the annotations and metadata are copied.
+func (s *ContentStore) Get(hash, uri string) (*UIResourceContents, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entries[hash]
+	if !ok {
+		return nil, false
+	}
+	rc := e.contents
+	rc.URI = uri
+	rc.cloneMetadata()
+	return &rc, true
+}
+
+// copyAnnotations returns a deep copy of a, or nil if a is nil.
+func copyAnnotations(a *Annotations) *Annotations {
+	if a == nil {
+		return nil
+	}
+	cp := &Annotations{Audience: slices.Clone(a.Audience)}
+	if a.Priority != nil {
+		p := *a.Priority
+		cp.Priority = &p
+	}
+	return cp
+}
+
+// cloneMetadata replaces the annotations and metadata of r with deep copies.
+// Metadata is copied through JSON, the form it is hashed in; metadata that
+// does not marshal is copied shallowly.
+func (r *UIResourceContents) cloneMetadata() {
+	r.Annotations = copyAnnotations(r.Annotations)
+	if r.Meta != nil {
+		var meta map[string]any
+		if err := remarshal(r.Meta, &meta); err != nil {
+			meta = maps.Clone(r.Meta)
+		}
+		r.Meta = meta
+	}
+}
+
+// Has reports whether contents with the given hash are stored.
+func (s *ContentStore) Has(hash string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.entries[hash]
+	return ok
+}
+
+// Release drops one reference to the contents with the given hash and
+// removes them when no references remain. It reports whether the contents
+// were removed.
+func (s *ContentStore) Release(hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[hash]
+	if !ok {
+		return false
+	}
+	e.refs--
+	if e.refs > 0 {
+		return false
+	}
+	delete(s.entries, hash)
+	return true
+}
+
+// Len returns the number of distinct contents stored.
+func (s *ContentStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
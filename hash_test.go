@@ -0,0 +1,131 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUIResourceContents_Hash(t *testing.T) {
+	a := &UIResourceContents{URI: "ui://a", MIMEType: MIMETypeHTML, Text: "<p>hi</p>"}
+	b := &UIResourceContents{URI: "ui://b", MIMEType: MIMETypeHTML, Text: "<p>hi</p>"}
+	assert.Equal(t, a.Hash(), b.Hash(), "URI is not part of the hash")
+	assert.Len(t, a.Hash(), 64)
+	assert.Equal(t, `"`+a.Hash()+`"`, a.ETag())
+
+	priority := 0.5
+	for _, other := range []*UIResourceContents{
+		{URI: "ui://a", MIMEType: MIMETypeHTML, Text: "<p>bye</p>"},
+		{URI: "ui://a", MIMEType: "text/plain", Text: "<p>hi</p>"},
+		{URI: "ui://a", MIMEType: MIMETypeHTML, Blob: []byte("<p>hi</p>")},
+		{URI: "ui://a", MIMEType: MIMETypeHTML, Text: "<p>hi</p>", Annotations: &Annotations{Priority: &priority}},
+	} {
+		assert.NotEqual(t, a.Hash(), other.Hash())
+	}
+}
+
+func TestReadUIResourceResult_ETag(t *testing.T) {
+	one := &UIResourceContents{URI: "ui://a", MIMEType: MIMETypeHTML, Text: "a"}
+	two := &UIResourceContents{URI: "ui://b", MIMEType: MIMETypeHTML, Text: "b"}
+	single := &ReadUIResourceResult{Contents: []*UIResourceContents{one}}
+	assert.Equal(t, one.ETag(), single.ETag())
+
+	ab := &ReadUIResourceResult{Contents: []*UIResourceContents{one, two}}
+	ba := &ReadUIResourceResult{Contents: []*UIResourceContents{two, one}}
+	assert.NotEqual(t, ab.ETag(), ba.ETag())
+}
+
+func TestReadIfModified(t *testing.T) {
+	r := NewResourceRegistry()
+	require.NoError(t, r.Register(&UIResource{URI: "ui://a", Name: "a"}, &HTMLContent{HTML: "v1"}))
+	ctx := context.Background()
+
+	result, etag, err := ReadIfModified(ctx, r, "ui://a", "")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		result, got, err := ReadIfModified(ctx, r, "ui://a", header)
+		assert.ErrorIs(t, err, ErrNotModified, header)
+		assert.Nil(t, result)
+		assert.Equal(t, etag, got)
+	}
+
+	require.NoError(t, r.Register(&UIResource{URI: "ui://a", Name: "a"}, &HTMLContent{HTML: "v2"}))
+	result, newETag, err := ReadIfModified(ctx, r, "ui://a", etag)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", result.Contents[0].Text)
+	assert.NotEqual(t, etag, newETag)
+
+	_, _, err = ReadIfModified(ctx, r, "ui://missing", etag)
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+}
+
+func TestContentStore(t *testing.T) {
+	s := NewContentStore()
+	a := &UIResourceContents{URI: "ui://a", MIMEType: MIMETypeHTML, Text: "shared"}
+	b := &UIResourceContents{URI: "ui://b", MIMEType: MIMETypeHTML, Text: "shared"}
+	c := &UIResourceContents{URI: "ui://c", MIMEType: "image/png", Blob: []byte{1, 2}}
+
+	ha := s.Put(a)
+	hb := s.Put(b)
+	hc := s.Put(c)
+	assert.Equal(t, ha, hb)
+	assert.Equal(t, 2, s.Len(), "identical contents are stored once")
+
+	got, ok := s.Get(ha, "ui://x")
+	require.True(t, ok)
+	assert.Equal(t, "ui://x", got.URI)
+	assert.Equal(t, "shared", got.Text)
+	got.Text = "mutated"
+	again, _ := s.Get(ha, "ui://y")
+	assert.Equal(t, "shared", again.Text, "Get returns a copy")
+
+	assert.False(t, s.Release(ha))
+	assert.True(t, s.Has(ha))
+	assert.True(t, s.Release(ha))
+	assert.False(t, s.Has(ha))
+	assert.False(t, s.Release(ha))
+	_, ok = s.Get(ha, "ui://a")
+	assert.False(t, ok)
+
+	got, ok = s.Get(hc, "ui://c")
+	require.True(t, ok)
+	assert.Equal(t, []byte{1, 2}, got.Blob)
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestContentStore_CopiesMetadata(t *testing.T) {
+	s := NewContentStore()
+	priority := 0.5
+	rc := &UIResourceContents{
+		MIMEType:    MIMETypeHTML,
+		Text:        "<p>x</p>",
+		Annotations: &Annotations{Audience: []string{"user"}, Priority: &priority},
+		Meta:        map[string]any{"ui": map[string]any{"prefersBorder": true}},
+	}
+	hash := s.Put(rc)
+
+	rc.Annotations.Audience[0] = "assistant"
+	priority = 1
+	rc.Meta["ui"].(map[string]any)["prefersBorder"] = false
+	rc.Meta["other"] = 1
+
+	got, ok := s.Get(hash, "ui://x")
+	require.True(t, ok)
+	assert.Equal(t, []string{"user"}, got.Annotations.Audience)
+	assert.Equal(t, 0.5, *got.Annotations.Priority)
+	assert.Equal(t, map[string]any{"ui": map[string]any{"prefersBorder": true}}, got.Meta)
+	assert.Equal(t, hash, got.Hash(), "later changes by the caller do not alter stored contents")
+
+	got.Meta["ui"].(map[string]any)["prefersBorder"] = false
+	got.Annotations.Audience[0] = "assistant"
+	again, _ := s.Get(hash, "ui://x")
+	assert.Equal(t, hash, again.Hash(), "Get returns a copy of the metadata")
+}
@@ -0,0 +1,322 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlTokenType identifies the kind of an htmlToken.
+type htmlTokenType int
+
+const (
+	// htmlText is character data between tags (left HTML-escaped as in the source).
+	htmlText htmlTokenType = iota
+	// htmlStartTag is an opening tag such as <div class="x">.
+	htmlStartTag
+	// htmlEndTag is a closing tag such as </div>.
+	htmlEndTag
+	// htmlSelfClosingTag is a tag written as <br/>.
+	htmlSelfClosingTag
+	// htmlComment is a <!-- ... --> comment.
+	htmlComment
+	// htmlDoctype is a <!DOCTYPE ...> declaration or other <!...>/<?...> markup.
+	htmlDoctype
+)
+
+// rawTextElements are elements whose content is not parsed as markup.
+// Their content is returned as a single htmlText token.
+var rawTextElements = map[string]bool{
+	"script":    true,
+	"style":     true,
+	"textarea":  true,
+	"title":     true,
+	"xmp":       true,
+	"iframe":    true,
+	"noembed":   true,
+	"noframes":  true,
+	"noscript":  true,
+	"plaintext": true,
+}
+
+// htmlAttr is a single tag attribute. Val holds the unescaped value.
+type htmlAttr struct {
+	Key string
+	Val string
+}
+
+// htmlToken is a lexical token produced by tokenizeHTML.
+type htmlToken struct {
+	Type htmlTokenType
+	// Data is the lower-cased tag name for tags, the raw source for text,
+	// and the inner content for comments and doctypes.
+	Data string
+	// Attrs holds the attributes of start and self-closing tags.
+	Attrs []htmlAttr
+	// Raw is the original source of the token.
+	Raw string
+}
+
+// attr returns the value of the named attribute.
+func (t *htmlToken) attr(key string) (string, bool) {
+	for _, a := range t.Attrs {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// setAttr sets or replaces the named attribute.
+func (t *htmlToken) setAttr(key, val string) {
+	for i := range t.Attrs {
+		if t.Attrs[i].Key == key {
+			t.Attrs[i].Val = val
+			return
+		}
+	}
+	t.Attrs = append(t.Attrs, htmlAttr{Key: key, Val: val})
+}
+
+// removeAttr deletes the named attribute if present.
+func (t *htmlToken) removeAttr(key string) {
+	attrs := t.Attrs[:0]
+	for _, a := range t.Attrs {
+		if a.Key != key {
+			attrs = append(attrs, a)
+		}
+	}
+	t.Attrs = attrs
+}
+
+// String renders the token back to HTML. Tags are re-serialized from
+// their parsed form so attribute edits are reflected; other tokens are
+// returned as they appeared in the source.
+func (t *htmlToken) String() string {
+	switch t.Type {
+	case htmlStartTag, htmlSelfClosingTag:
+		var b strings.Builder
+		b.WriteByte('<')
+		b.WriteString(t.Data)
+		for _, a := range t.Attrs {
+			b.WriteByte(' ')
+			b.WriteString(a.Key)
+			if a.Val != "" {
+				b.WriteString(`="`)
+				b.WriteString(html.EscapeString(a.Val))
+				b.WriteByte('"')
+			}
+		}
+		if t.Type == htmlSelfClosingTag {
+			b.WriteString("/>")
+		} else {
+			b.WriteByte('>')
+		}
+		return b.String()
+	case htmlEndTag:
+		return "</" + t.Data + ">"
+	default:
+		return t.Raw
+	}
+}
+
+// tokenizeHTML splits an HTML document or fragment into tokens.
+//
+// It is a small, forgiving tokenizer in the spirit of the HTML5 tokenizer:
+// it never fails, treats stray '<' characters as text, and returns the
+// content of raw text elements such as <script> and <style> as a single
+// text token. It does not build a tree or apply insertion-mode rules.
+func tokenizeHTML(s string) []htmlToken {
+	var tokens []htmlToken
+	textStart := 0
+	flushText := func(end int) {
+		if end > textStart {
+			tokens = append(tokens, htmlToken{Type: htmlText, Data: s[textStart:end], Raw: s[textStart:end]})
+		}
+	}
+
+	i := 0
+	for i < len(s) {
+		if s[i] != '<' || i+1 >= len(s) {
+			i++
+			continue
+		}
+		next := s[i+1]
+		switch {
+		case strings.HasPrefix(s[i:], "<!--"):
+			flushText(i)
+			end := strings.Index(s[i+4:], "-->")
+			var raw, data string
+			if end == -1 {
+				raw, data = s[i:], s[i+4:]
+			} else {
+				raw, data = s[i:i+4+end+3], s[i+4:i+4+end]
+			}
+			tokens = append(tokens, htmlToken{Type: htmlComment, Data: data, Raw: raw})
+			i += len(raw)
+			textStart = i
+		case next == '!' || next == '?':
+			flushText(i)
+			end := strings.IndexByte(s[i:], '>')
+			raw := s[i:]
+			if end != -1 {
+				raw = s[i : i+end+1]
+			}
+			data := strings.TrimSuffix(raw[2:], ">")
+			tokens = append(tokens, htmlToken{Type: htmlDoctype, Data: data, Raw: raw})
+			i += len(raw)
+			textStart = i
+		case next == '/' && i+2 < len(s) && isASCIILetter(s[i+2]):
+			flushText(i)
+			nameEnd := i + 2
+			for nameEnd < len(s) && !isTagNameEnd(s[nameEnd]) {
+				nameEnd++
+			}
+			end := strings.IndexByte(s[nameEnd:], '>')
+			raw := s[i:]
+			if end != -1 {
+				raw = s[i : nameEnd+end+1]
+			}
+			tokens = append(tokens, htmlToken{Type: htmlEndTag, Data: strings.ToLower(s[i+2 : nameEnd]), Raw: raw})
+			i += len(raw)
+			textStart = i
+		case isASCIILetter(next):
+			flushText(i)
+			tok, n := parseStartTag(s[i:])
+			tokens = append(tokens, tok)
+			i += n
+			textStart = i
+			if tok.Type == htmlStartTag && rawTextElements[tok.Data] {
+				end := indexEndTag(s[i:], tok.Data)
+				if end == -1 {
+					end = len(s) - i
+				}
+				if end > 0 {
+					tokens = append(tokens, htmlToken{Type: htmlText, Data: s[i : i+end], Raw: s[i : i+end]})
+				}
+				i += end
+				textStart = i
+			}
+		default:
+			i++
+		}
+	}
+	flushText(len(s))
+	return tokens
+}
+
+// parseStartTag parses a start tag at the beginning of s and returns the
+// token and the number of bytes consumed.
+func parseStartTag(s string) (htmlToken, int) {
+	i := 1
+	for i < len(s) && !isTagNameEnd(s[i]) {
+		i++
+	}
+	tok := htmlToken{Type: htmlStartTag, Data: strings.ToLower(s[1:i])}
+
+	for i < len(s) {
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '>' {
+			i++
+			break
+		}
+		if s[i] == '/' {
+			if i+1 < len(s) && s[i+1] == '>' {
+				tok.Type = htmlSelfClosingTag
+				i += 2
+				break
+			}
+			i++
+			continue
+		}
+
+		keyStart := i
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '=' && s[i] != '>' && !(s[i] == '/' && i > keyStart) {
+			i++
+		}
+		key := strings.ToLower(s[keyStart:i])
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		val := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end == -1 {
+					val = s[i+1:]
+					i = len(s)
+				} else {
+					val = s[i+1 : i+1+end]
+					i += end + 2
+				}
+			} else {
+				valStart := i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				val = s[valStart:i]
+			}
+		}
+		if key != "" {
+			if _, dup := tok.attr(key); !dup {
+				tok.Attrs = append(tok.Attrs, htmlAttr{Key: key, Val: html.UnescapeString(val)})
+			}
+		}
+	}
+	tok.Raw = s[:i]
+	return tok, i
+}
+
+// indexEndTag returns the index of the closing tag for name in s,
+// matched case-insensitively, or -1 if there is none.
+func indexEndTag(s, name string) int {
+	lower := strings.ToLower(s)
+	needle := "</" + name
+	offset := 0
+	for {
+		idx := strings.Index(lower[offset:], needle)
+		if idx == -1 {
+			return -1
+		}
+		pos := offset + idx
+		after := pos + len(needle)
+		if after >= len(s) || isTagNameEnd(s[after]) {
+			return pos
+		}
+		offset = after
+	}
+}
+
+// renderHTML serializes tokens back into HTML.
+func renderHTML(tokens []htmlToken) string {
+	var b strings.Builder
+	for i := range tokens {
+		b.WriteString(tokens[i].String())
+	}
+	return b.String()
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isTagNameEnd(c byte) bool {
+	return isHTMLSpace(c) || c == '>' || c == '/'
+}
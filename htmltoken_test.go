@@ -0,0 +1,63 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenizeHTML(t *testing.T) {
+	src := `<!DOCTYPE html><!-- c --><div class="a" data-x='1 &amp; 2' hidden>Hi <b>there</b><br/></div>`
+	tokens := tokenizeHTML(src)
+
+	types := make([]htmlTokenType, len(tokens))
+	for i, tok := range tokens {
+		types[i] = tok.Type
+	}
+	assert.Equal(t, []htmlTokenType{
+		htmlDoctype, htmlComment, htmlStartTag, htmlText, htmlStartTag, htmlText, htmlEndTag, htmlSelfClosingTag, htmlEndTag,
+	}, types)
+
+	div := tokens[2]
+	assert.Equal(t, "div", div.Data)
+	v, ok := div.attr("data-x")
+	require.True(t, ok)
+	assert.Equal(t, "1 & 2", v)
+	_, ok = div.attr("hidden")
+	assert.True(t, ok)
+	assert.Equal(t, " c ", tokens[1].Data)
+}
+
+func TestTokenizeHTML_RawText(t *testing.T) {
+	src := `<SCRIPT type="module">if (a < b) { x = "</div>"; }</script ><p>after</p>`
+	tokens := tokenizeHTML(src)
+	require.GreaterOrEqual(t, len(tokens), 3)
+
+	assert.Equal(t, htmlStartTag, tokens[0].Type)
+	assert.Equal(t, "script", tokens[0].Data)
+	assert.Equal(t, htmlText, tokens[1].Type)
+	assert.Equal(t, `if (a < b) { x = "</div>"; }`, tokens[1].Raw)
+	assert.Equal(t, htmlEndTag, tokens[2].Type)
+	assert.Equal(t, "script", tokens[2].Data)
+}
+
+func TestTokenizeHTML_StrayLessThan(t *testing.T) {
+	tokens := tokenizeHTML("1 < 2 and <3")
+	require.Len(t, tokens, 1)
+	assert.Equal(t, htmlText, tokens[0].Type)
+	assert.Equal(t, "1 < 2 and <3", tokens[0].Raw)
+}
+
+func TestHTMLToken_String(t *testing.T) {
+	tokens := tokenizeHTML(`<a href="x?a=1&amp;b=2" title='say "hi"'>link</a>`)
+	require.Len(t, tokens, 3)
+
+	tokens[0].setAttr("rel", "noopener")
+	tokens[0].removeAttr("title")
+	assert.Equal(t, `<a href="x?a=1&amp;b=2" rel="noopener">link</a>`, renderHTML(tokens))
+}
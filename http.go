@@ -0,0 +1,285 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Request parameters read by [ActionHTTPHandler].
+const (
+	// HeaderResourceURI carries the URI of the resource that sent the action.
+	HeaderResourceURI = "X-MCP-UI-Resource-URI"
+	// QueryResourceURI is the query parameter alternative to HeaderResourceURI.
+	QueryResourceURI = "resourceUri"
+)
+
+// Default limits for [ActionHTTPHandler].
+const (
+	// DefaultMaxRequestSize is the default request body limit in bytes.
+	DefaultMaxRequestSize = 1 << 20 // 1 MiB
+	// DefaultMaxBatchSize is the default number of actions per batch.
+	DefaultMaxBatchSize = 32
+)
+
+// Error codes set in [ResponseError].Code by [ActionHTTPHandler].
+const (
+	// ErrorCodeInvalidRequest reports a malformed request or action.
+	ErrorCodeInvalidRequest = "invalid_request"
+	// ErrorCodeRequestTooLarge reports a body or batch over the limits.
+	ErrorCodeRequestTooLarge = "request_too_large"
+	// ErrorCodeUnauthorized reports a request without a valid session.
+	ErrorCodeUnauthorized = "unauthorized"
+	// ErrorCodeNoHandler reports an action no handler accepts.
+	ErrorCodeNoHandler = "no_handler"
+	// ErrorCodeInternal reports a failure while dispatching an action.
+	ErrorCodeInternal = "internal_error"
+)
+
+// ActionDispatcher routes UI actions to handlers. [Router] implements it.
+type ActionDispatcher interface {
+	Dispatch(ctx context.Context, req *UIActionRequest) (*UIActionResult, error)
+}
+
+// PublicError marks an error whose message may be sent to clients. When a
+// dispatcher returns any other error, [ActionHTTPHandler] answers with a
+// generic message so that internal details do not leak.
+//
+// Example:
+//
+//	return nil, &mcpui.PublicError{Err: errors.New("order is closed")}
+type PublicError struct {
+	Err error
+}
+
+func (e *PublicError) Error() string { return e.Err.Error() }
+
+func (e *PublicError) Unwrap() error { return e.Err }
+
+// SessionExtractor derives the session passed to handlers as
+// [UIActionRequest].Session from an HTTP request. Returning an error
+// rejects the request with 401 Unauthorized.
+type SessionExtractor func(*http.Request) (any, error)
+
+// ErrNoSession is returned by the session extractors of this package when
+// the request carries no session.
+var ErrNoSession = errors.New("no session")
+
+// SessionFromHeader returns a SessionExtractor that uses the value of the
+// named header as the session.
+func SessionFromHeader(name string) SessionExtractor {
+	return func(r *http.Request) (any, error) {
+		v := r.Header.Get(name)
+		if v == "" {
+			return nil, fmt.Errorf("%w: missing %s header", ErrNoSession, name)
+		}
+		return v, nil
+	}
+}
+
+// SessionFromCookie returns a SessionExtractor that uses the value of the
+// named cookie as the session.
+func SessionFromCookie(name string) SessionExtractor {
+	return func(r *http.Request) (any, error) {
+		c, err := r.Cookie(name)
+		if err != nil || c.Value == "" {
+			return nil, fmt.Errorf("%w: missing %s cookie", ErrNoSession, name)
+		}
+		return c.Value, nil
+	}
+}
+
+// ActionHTTPHandler serves UI actions over HTTP. Create it with
+// [HTTPHandler].
+//
+// Clients POST a JSON [UIAction], or a JSON array of actions as a batch.
+// The originating resource URI is read from the X-MCP-UI-Resource-URI
+// header or the resourceUri query parameter. The response body is a
+// [UIResponse], or an array of responses in batch order.
+//
+// Status codes:
+//
+//   - 200 for a dispatched action, including handler errors reported in
+//     the UIResponse, and for every batch
+//   - 400 for malformed JSON, an invalid action, payload or resource URI
+//   - 401 when the SessionExtractor rejects the request
+//   - 404 when no handler matches a single action
+//   - 405 for methods other than POST
+//   - 413 when the body or batch exceeds the limits
+//   - 415 for a missing or non-JSON content type
+//   - 500 when dispatching a single action fails
+//
+// Requiring application/json keeps other sites from posting actions with a
+// user's cookies: browsers only send that content type cross-site after a
+// CORS preflight. Dispatch errors are reported with a generic message
+// unless they are a [PublicError].
+//
+// Example:
+//
+//	router := mcpui.NewRouter()
+//	router.HandleType(mcpui.ActionTypeTool, mcpui.WrapToolHandler(callTool))
+//
+//	h := mcpui.HTTPHandler(router)
+//	h.SessionExtractor = mcpui.SessionFromCookie("session")
+//	http.Handle("/mcp-ui/actions", h)
+type ActionHTTPHandler struct {
+	// MaxRequestSize limits the request body in bytes. Zero means
+	// DefaultMaxRequestSize.
+	MaxRequestSize int64
+	// MaxBatchSize limits the number of actions per batch. Zero means
+	// DefaultMaxBatchSize.
+	MaxBatchSize int
+	// SessionExtractor, if set, provides the session for each request.
+	SessionExtractor SessionExtractor
+
+	dispatcher ActionDispatcher
+}
+
+// HTTPHandler returns an http.Handler dispatching UI actions to d.
+func HTTPHandler(d ActionDispatcher) *ActionHTTPHandler {
+	return &ActionHTTPHandler{dispatcher: d}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *ActionHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeHTTPError(w, http.StatusMethodNotAllowed, ErrorCodeInvalidRequest, "method must be POST")
+		return
+	}
+	if mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mt != "application/json" {
+		writeHTTPError(w, http.StatusUnsupportedMediaType, ErrorCodeInvalidRequest, "content type must be application/json")
+		return
+	}
+
+	resourceURI := r.Header.Get(HeaderResourceURI)
+	if resourceURI == "" {
+		resourceURI = r.URL.Query().Get(QueryResourceURI)
+	}
+	if resourceURI != "" && !strings.HasPrefix(resourceURI, URIScheme) {
+		writeHTTPError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, "resource URI must start with "+URIScheme)
+		return
+	}
+
+	var session any
+	if h.SessionExtractor != nil {
+		s, err := h.SessionExtractor(r)
+		if err != nil {
+			writeHTTPError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, err.Error())
+			return
+		}
+		session = s
+	}
+
+	maxSize := h.MaxRequestSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxRequestSize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeHTTPError(w, http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge,
+				fmt.Sprintf("request body exceeds %d bytes", maxSize))
+			return
+		}
+		writeHTTPError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, "reading request body: "+err.Error())
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		h.serveBatch(w, r.Context(), body, resourceURI, session)
+		return
+	}
+
+	var action UIAction
+	if err := json.Unmarshal(body, &action); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, "invalid action: "+err.Error())
+		return
+	}
+	status, resp := dispatchAction(r.Context(), h.dispatcher, &action, resourceURI, session)
+	writeJSON(w, status, resp)
+}
+
+func (h *ActionHTTPHandler) serveBatch(w http.ResponseWriter, ctx context.Context, body []byte, resourceURI string, session any) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, "invalid batch: "+err.Error())
+		return
+	}
+	if len(raw) == 0 {
+		writeHTTPError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, "empty batch")
+		return
+	}
+	maxBatch := h.MaxBatchSize
+	if maxBatch <= 0 {
+		maxBatch = DefaultMaxBatchSize
+	}
+	if len(raw) > maxBatch {
+		writeHTTPError(w, http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge,
+			fmt.Sprintf("batch has %d actions, limit %d", len(raw), maxBatch))
+		return
+	}
+
+	responses := make([]*UIResponse, len(raw))
+	for i, item := range raw {
+		var action UIAction
+		if err := json.Unmarshal(item, &action); err != nil {
+			responses[i] = NewErrorResponseWithCode("", ErrorCodeInvalidRequest, "invalid action: "+err.Error())
+			continue
+		}
+		_, responses[i] = dispatchAction(ctx, h.dispatcher, &action, resourceURI, session)
+	}
+	writeJSON(w, http.StatusOK, responses)
+}
+
+// dispatchAction dispatches one action to d and returns the response with
+// the status code to use if it is the only action in the request.
+func dispatchAction(ctx context.Context, d ActionDispatcher, action *UIAction, resourceURI string, session any) (int, *UIResponse) {
+	if action.Type == "" {
+		return http.StatusBadRequest, NewErrorResponseWithCode(action.MessageID, ErrorCodeInvalidRequest, "action type is required")
+	}
+	result, err := d.Dispatch(ctx, &UIActionRequest{
+		Action:      action,
+		ResourceURI: resourceURI,
+		Session:     session,
+	})
+	switch {
+	case errors.Is(err, ErrNoHandler):
+		return http.StatusNotFound, NewErrorResponseWithCode(action.MessageID, ErrorCodeNoHandler, err.Error())
+	case errors.Is(err, ErrInvalidPayload):
+		return http.StatusBadRequest, NewErrorResponseWithCode(action.MessageID, ErrorCodeInvalidRequest, err.Error())
+	case err != nil:
+		message := "internal error"
+		var public *PublicError
+		if errors.As(err, &public) {
+			message = public.Error()
+		}
+		return http.StatusInternalServerError, NewErrorResponseWithCode(action.MessageID, ErrorCodeInternal, message)
+	case result == nil:
+		return http.StatusOK, NewSuccessResponse(action.MessageID, nil)
+	}
+	return http.StatusOK, result.ToUIResponse(action.MessageID)
+}
+
+func writeHTTPError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, NewErrorResponseWithCode("", code, message))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v) // the status is already sent
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHTTPRouter() *Router {
+	r := NewRouter()
+	r.HandleType(ActionTypeTool, WrapToolHandler(func(ctx context.Context, name string, params map[string]any) (any, error) {
+		if name == "fail" {
+			return nil, errors.New("tool failed")
+		}
+		return map[string]any{"tool": name}, nil
+	}))
+	r.HandleType(ActionTypeNotify, func(ctx context.Context, req *UIActionRequest) (*UIActionResult, error) {
+		return &UIActionResult{Response: map[string]any{"session": req.Session, "resource": req.ResourceURI}}, nil
+	})
+	r.HandleType(ActionTypePrompt, func(context.Context, *UIActionRequest) (*UIActionResult, error) {
+		return nil, errors.New("database down")
+	})
+	r.HandleType(ActionTypeIntent, func(context.Context, *UIActionRequest) (*UIActionResult, error) {
+		return nil, fmt.Errorf("checking order: %w", &PublicError{Err: errors.New("order is closed")})
+	})
+	return r
+}
+
+func postAction(t *testing.T, h http.Handler, target, body string, header http.Header) (*httptest.ResponseRecorder, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v[0])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec, rec.Body.Bytes()
+}
+
+func decodeUIResponse(t *testing.T, data []byte) *UIResponse {
+	t.Helper()
+	var resp UIResponse
+	require.NoError(t, json.Unmarshal(data, &resp))
+	return &resp
+}
+
+func TestHTTPHandler_Single(t *testing.T) {
+	h := HTTPHandler(testHTTPRouter())
+
+	rec, body := postAction(t, h, "/actions", `{"type":"tool","messageId":"m1","payload":{"toolName":"refresh"}}`, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	resp := decodeUIResponse(t, body)
+	assert.Equal(t, ResponseTypeResponse, resp.Type)
+	assert.Equal(t, "m1", resp.MessageID)
+	assert.Equal(t, map[string]any{"tool": "refresh"}, resp.GetResponse())
+
+	rec, body = postAction(t, h, "/actions", `{"type":"tool","messageId":"m2","payload":{"toolName":"fail"}}`, nil)
+	assert.Equal(t, http.StatusOK, rec.Code, "handler errors are reported in the UIResponse")
+	assert.Equal(t, "tool failed", decodeUIResponse(t, body).GetError().Message)
+}
+
+func TestHTTPHandler_ResourceURIAndSession(t *testing.T) {
+	h := HTTPHandler(testHTTPRouter())
+	h.SessionExtractor = SessionFromHeader("X-Session")
+	action := `{"type":"notify","payload":{"message":"hi"}}`
+
+	rec, body := postAction(t, h, "/actions", action, http.Header{
+		"X-Session":       {"s1"},
+		HeaderResourceURI: {"ui://dash"},
+	})
+	require.Equal(t, http.StatusOK, rec.Code, string(body))
+	assert.Equal(t, map[string]any{"session": "s1", "resource": "ui://dash"}, decodeUIResponse(t, body).GetResponse())
+
+	rec, body = postAction(t, h, "/actions?resourceUri=ui://query", action, http.Header{"X-Session": {"s1"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ui://query", decodeUIResponse(t, body).GetResponse().(map[string]any)["resource"])
+
+	rec, body = postAction(t, h, "/actions", action, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, ErrorCodeUnauthorized, decodeUIResponse(t, body).GetError().Code)
+
+	rec, _ = postAction(t, h, "/actions", action, http.Header{"X-Session": {"s1"}, HeaderResourceURI: {"https://evil"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSessionFromCookie(t *testing.T) {
+	extract := SessionFromCookie("sid")
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	_, err := extract(req)
+	assert.ErrorIs(t, err, ErrNoSession)
+
+	req.AddCookie(&http.Cookie{Name: "sid", Value: "abc"})
+	session, err := extract(req)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", session)
+}
+
+func TestHTTPHandler_Batch(t *testing.T) {
+	h := HTTPHandler(testHTTPRouter())
+	rec, body := postAction(t, h, "/actions", `[
+		{"type":"tool","messageId":"a","payload":{"toolName":"one"}},
+		{"type":"link","messageId":"b","payload":{"url":"https://example.com"}},
+		{"type":"","messageId":"c"},
+		42
+	]`, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var responses []*UIResponse
+	require.NoError(t, json.Unmarshal(body, &responses))
+	require.Len(t, responses, 4)
+	assert.True(t, responses[0].IsSuccess())
+	assert.Equal(t, ErrorCodeNoHandler, responses[1].GetError().Code)
+	assert.Equal(t, "b", responses[1].MessageID)
+	assert.Equal(t, ErrorCodeInvalidRequest, responses[2].GetError().Code)
+	assert.Equal(t, ErrorCodeInvalidRequest, responses[3].GetError().Code)
+
+	rec, _ = postAction(t, h, "/actions", `[]`, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	h.MaxBatchSize = 1
+	rec, body = postAction(t, h, "/actions", `[{"type":"tool"},{"type":"tool"}]`, nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, ErrorCodeRequestTooLarge, decodeUIResponse(t, body).GetError().Code)
+}
+
+func TestHTTPHandler_Errors(t *testing.T) {
+	h := HTTPHandler(testHTTPRouter())
+	h.MaxRequestSize = 64
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{"malformed JSON", `{"type":`, http.StatusBadRequest, ErrorCodeInvalidRequest},
+		{"missing type", `{"payload":{}}`, http.StatusBadRequest, ErrorCodeInvalidRequest},
+		{"no handler", `{"type":"link","payload":{}}`, http.StatusNotFound, ErrorCodeNoHandler},
+		{"malformed payload", `{"type":"tool","payload":{"toolName":5}}`, http.StatusBadRequest, ErrorCodeInvalidRequest},
+		{"dispatch error", `{"type":"prompt","payload":{}}`, http.StatusInternalServerError, ErrorCodeInternal},
+		{"too large", `{"type":"tool","payload":{"toolName":"` + strings.Repeat("x", 100) + `"}}`, http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, body := postAction(t, h, "/actions", tt.body, nil)
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.code, decodeUIResponse(t, body).GetError().Code)
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/actions", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+
+	for _, ct := range []string{"text/plain", ""} {
+		req := httptest.NewRequest(http.MethodPost, "/actions", strings.NewReader(`{"type":"notify","payload":{}}`))
+		if ct != "" {
+			req.Header.Set("Content-Type", ct)
+		}
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code, "content type %q", ct)
+	}
+}
+
+func TestHTTPHandler_ErrorMessages(t *testing.T) {
+	h := HTTPHandler(testHTTPRouter())
+
+	rec, body := postAction(t, h, "/actions", `{"type":"prompt","payload":{"prompt":"x"}}`, nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "internal error", decodeUIResponse(t, body).GetError().Message, "handler errors do not leak")
+
+	rec, body = postAction(t, h, "/actions", `{"type":"intent","payload":{"intent":"x"}}`, nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "order is closed", decodeUIResponse(t, body).GetError().Message)
+}
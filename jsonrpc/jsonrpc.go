@@ -0,0 +1,443 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package jsonrpc is a minimal JSON-RPC 2.0 server for serving UI resources
+// over MCP without depending on an MCP SDK.
+//
+// A [Server] dispatches requests to [Handler] functions by method name and
+// serves newline-delimited JSON, the framing of the MCP stdio transport,
+// over any [io.ReadWriter]. Each connection is a session that the server
+// can send notifications to, so a *Server is an [mcpui.Notifier].
+// [RegisterResources] adds the MCP resources methods backed by a
+// [ResourceSource] such as *mcpui.ResourceRegistry:
+//
+//	registry := mcpui.NewResourceRegistry()
+//	registry.Register(resource, content)
+//
+//	srv := jsonrpc.NewServer()
+//	jsonrpc.RegisterResources(srv, registry)
+//	registry.SetNotifier(srv)
+//	srv.Handle("initialize", initialize)
+//	log.Fatal(srv.ServeStdio(context.Background()))
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	mcpui "github.com/ironystock/mcpui-go"
+)
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Defaults for [Server].
+const (
+	// DefaultMaxMessageSize is the default limit of one message in bytes.
+	DefaultMaxMessageSize = 1 << 20 // 1 MiB
+	// DefaultMaxConcurrent is the default number of messages handled at
+	// once per connection.
+	DefaultMaxConcurrent = 32
+)
+
+// CodeResourceNotFound is the MCP error code for reading an unknown
+// resource.
+const CodeResourceNotFound = -32002
+
+// Request is a JSON-RPC 2.0 request or, without an ID, a notification.
+type Request struct {
+	// JSONRPC is always "2.0".
+	JSONRPC string `json:"jsonrpc"`
+	// ID identifies the request. It is empty for notifications.
+	ID json.RawMessage `json:"id,omitempty"`
+	// Method is the method to invoke.
+	Method string `json:"method"`
+	// Params holds the raw method parameters, if any.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// IsNotification reports whether the request expects no response.
+func (r *Request) IsNotification() bool {
+	return len(r.ID) == 0
+}
+
+// Response is a JSON-RPC 2.0 response.
+type Response struct {
+	// JSONRPC is always "2.0".
+	JSONRPC string `json:"jsonrpc"`
+	// ID is the ID of the request, or null if it could not be determined.
+	ID json.RawMessage `json:"id"`
+	// Result holds the result on success.
+	Result json.RawMessage `json:"result,omitempty"`
+	// Error holds the error on failure.
+	Error *Error `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object. Handlers return an *Error to choose
+// the code sent to the client.
+type Error struct {
+	// Code is the error code.
+	Code int `json:"code"`
+	// Message is a short description of the error.
+	Message string `json:"message"`
+	// Data contains additional error context.
+	Data any `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// Handler handles the requests of one method. The returned result is
+// encoded as JSON; a nil result is sent as an empty object. Errors other
+// than an *Error or the mcpui errors listed at [RegisterResources] are sent
+// as [CodeInternalError] with a generic message, so that internal details
+// do not leak; so is a panic.
+type Handler func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Server dispatches JSON-RPC requests to handlers. It is safe for
+// concurrent use.
+type Server struct {
+	// MaxMessageSize limits the size of one message, a line of input, in
+	// bytes. Zero means DefaultMaxMessageSize.
+	MaxMessageSize int
+	// MaxConcurrent limits the messages handled at once per connection;
+	// Serve stops reading while the limit is reached. Zero means
+	// DefaultMaxConcurrent.
+	MaxConcurrent int
+
+	mu          sync.RWMutex
+	methods     map[string]Handler
+	sessions    map[string]*conn
+	lastSession uint64
+	onClose     []func(sessionID string)
+}
+
+// conn is the writing side of a session.
+type conn struct {
+	mu     sync.Mutex
+	w      io.Writer
+	err    error
+	cancel context.CancelFunc
+}
+
+// write writes one message line. After the first failure it cancels the
+// session and reports that error for every later message.
+func (c *conn) write(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	if _, err := c.w.Write(append(msg, '\n')); err != nil {
+		c.err = err
+		c.cancel()
+	}
+	return c.err
+}
+
+type sessionKey struct{}
+
+// SessionID returns the ID of the session a request arrived on, or "" if
+// the request was not received by [Server.Serve].
+func SessionID(ctx context.Context) string {
+	id, _ := ctx.Value(sessionKey{}).(string)
+	return id
+}
+
+// NewServer creates a server with no methods.
+func NewServer() *Server {
+	return &Server{methods: make(map[string]Handler), sessions: make(map[string]*conn)}
+}
+
+// Handle registers the handler for a method, replacing any previous one.
+func (s *Server) Handle(method string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.methods[method] = h
+}
+
+// Call dispatches one request and returns its response, or nil for a
+// notification.
+func (s *Server) Call(ctx context.Context, req *Request) *Response {
+	if req.JSONRPC != mcpui.JSONRPCVersion || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: CodeInvalidRequest, Message: "invalid request"})
+	}
+	s.mu.RLock()
+	h, ok := s.methods[req.Method]
+	s.mu.RUnlock()
+
+	var (
+		result any
+		err    error
+	)
+	if ok {
+		result, err = callHandler(ctx, h, req.Params)
+	} else {
+		err = &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+	if req.IsNotification() {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, toError(err))
+	}
+	if result == nil {
+		result = struct{}{}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, internalError())
+	}
+	return &Response{JSONRPC: mcpui.JSONRPCVersion, ID: req.ID, Result: data}
+}
+
+// callHandler calls h, turning a panic into an internal error.
+func callHandler(ctx context.Context, h Handler, params json.RawMessage) (result any, err error) {
+	defer func() {
+		if recover() != nil {
+			result, err = nil, internalError()
+		}
+	}()
+	return h(ctx, params)
+}
+
+// OnSessionClose registers f to be called with the ID of each session
+// whose connection closes.
+func (s *Server) OnSessionClose(f func(sessionID string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onClose = append(s.onClose, f)
+}
+
+// Notify sends a notification to one session. It implements
+// [mcpui.Notifier].
+func (s *Server) Notify(ctx context.Context, sessionID string, n *mcpui.Notification) error {
+	s.mu.RLock()
+	c, ok := s.sessions[sessionID]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("jsonrpc: unknown session %q", sessionID)
+	}
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return c.write(data)
+}
+
+// Broadcast sends a notification to every open session. It implements
+// [mcpui.Notifier].
+func (s *Server) Broadcast(ctx context.Context, n *mcpui.Notification) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	conns := make(map[string]*conn, len(s.sessions))
+	maps.Copy(conns, s.sessions)
+	s.mu.RUnlock()
+
+	var errs []error
+	for id, c := range conns {
+		if err := c.write(data); err != nil {
+			errs = append(errs, fmt.Errorf("session %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// handleMessage handles one message, a request or a batch, and returns the
+// encoded reply or nil if there is none.
+func (s *Server) handleMessage(ctx context.Context, msg []byte) []byte {
+	if msg[0] != '[' {
+		var req Request
+		if err := json.Unmarshal(msg, &req); err != nil {
+			return encode(parseError(err))
+		}
+		if resp := s.Call(ctx, &req); resp != nil {
+			return encode(resp)
+		}
+		return nil
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err != nil {
+		return encode(parseError(err))
+	}
+	if len(batch) == 0 {
+		return encode(errorResponse(nil, &Error{Code: CodeInvalidRequest, Message: "empty batch"}))
+	}
+	var responses []*Response
+	for _, item := range batch {
+		var req Request
+		if err := json.Unmarshal(item, &req); err != nil {
+			responses = append(responses, errorResponse(nil, &Error{Code: CodeInvalidRequest, Message: "invalid request"}))
+			continue
+		}
+		if resp := s.Call(ctx, &req); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+	return encode(responses)
+}
+
+// Serve reads newline-delimited JSON-RPC messages from rw and writes the
+// responses to it, one per line. Requests are handled concurrently, up to
+// MaxConcurrent at a time, so responses may arrive out of order. Serve
+// returns when rw reaches EOF, after all pending requests are answered, or
+// on the first read or write error. A message over MaxMessageSize is
+// answered with [CodeInvalidRequest] and ends Serve with
+// [bufio.ErrTooLong]. Handlers receive a context that is canceled when Serve returns
+// and that carries the connection's session ID, see [SessionID].
+func (s *Server) Serve(ctx context.Context, rw io.ReadWriter) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c := &conn{w: rw, cancel: cancel}
+	s.mu.Lock()
+	s.lastSession++
+	sessionID := strconv.FormatUint(s.lastSession, 10)
+	s.sessions[sessionID] = c
+	s.mu.Unlock()
+	defer s.closeSession(sessionID)
+	ctx = context.WithValue(ctx, sessionKey{}, sessionID)
+
+	maxSize := s.MaxMessageSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxMessageSize
+	}
+	maxConcurrent := s.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrent
+	}
+
+	var wg sync.WaitGroup
+	write := func(reply []byte) { _ = c.write(reply) } // the error ends Serve
+	sem := make(chan struct{}, maxConcurrent)
+
+	sc := bufio.NewScanner(rw)
+	sc.Buffer(make([]byte, 0, min(maxSize, 64<<10)), maxSize)
+read:
+	for sc.Scan() {
+		msg := bytes.TrimSpace(sc.Bytes())
+		if len(msg) == 0 {
+			continue
+		}
+		msg = bytes.Clone(msg) // the scanner reuses its buffer
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break read
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if reply := s.handleMessage(ctx, msg); reply != nil {
+				write(reply)
+			}
+		}()
+	}
+	readErr := sc.Err()
+	if errors.Is(readErr, bufio.ErrTooLong) {
+		write(encode(errorResponse(nil, &Error{Code: CodeInvalidRequest, Message: "message too large"})))
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if readErr != nil {
+		return readErr
+	}
+	if c.err != nil {
+		return c.err
+	}
+	return ctx.Err()
+}
+
+// closeSession forgets a session and runs the OnSessionClose callbacks.
+func (s *Server) closeSession(sessionID string) {
+	s.mu.Lock()
+	delete(s.sessions, sessionID)
+	onClose := slices.Clone(s.onClose)
+	s.mu.Unlock()
+	for _, f := range onClose {
+		f(sessionID)
+	}
+}
+
+// ServeStdio serves requests from standard input, writing responses to
+// standard output.
+func (s *Server) ServeStdio(ctx context.Context) error {
+	return s.Serve(ctx, stdio{})
+}
+
+type stdio struct{}
+
+func (stdio) Read(p []byte) (int, error)  { return os.Stdin.Read(p) }
+func (stdio) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+
+// toError maps a handler error onto a JSON-RPC error.
+func toError(err error) *Error {
+	var rpcErr *Error
+	switch {
+	case errors.As(err, &rpcErr):
+		return rpcErr
+	case errors.Is(err, mcpui.ErrResourceNotFound):
+		return &Error{Code: CodeResourceNotFound, Message: err.Error()}
+	case errors.Is(err, mcpui.ErrInvalidCursor):
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	default:
+		return internalError()
+	}
+}
+
+// internalError returns the error sent for failures whose details must not
+// reach the client.
+func internalError() *Error {
+	return &Error{Code: CodeInternalError, Message: "internal error"}
+}
+
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &Response{JSONRPC: mcpui.JSONRPCVersion, ID: id, Error: err}
+}
+
+func parseError(err error) *Response {
+	return errorResponse(nil, &Error{Code: CodeParseError, Message: "parse error: " + err.Error()})
+}
+
+func encode(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Responses hold only encoded results and error data from handlers.
+		data, _ = json.Marshal(errorResponse(nil, internalError()))
+	}
+	return data
+}
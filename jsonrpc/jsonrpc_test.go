@@ -0,0 +1,211 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pipeConn is one end of an in-memory connection.
+type pipeConn struct {
+	io.Reader
+	io.Writer
+}
+
+// testClient talks to a server over in-memory pipes.
+type testClient struct {
+	t     *testing.T
+	w     *io.PipeWriter
+	lines *bufio.Scanner
+	done  chan error
+}
+
+func startServer(t *testing.T, s *Server) *testClient {
+	t.Helper()
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	c := &testClient{t: t, w: clientW, lines: bufio.NewScanner(clientR), done: make(chan error, 1)}
+	go func() {
+		err := s.Serve(context.Background(), pipeConn{serverR, serverW})
+		serverW.Close()
+		c.done <- err
+	}()
+	t.Cleanup(func() { clientW.Close() })
+	return c
+}
+
+func (c *testClient) send(msg string) {
+	c.t.Helper()
+	_, err := io.WriteString(c.w, msg+"\n")
+	require.NoError(c.t, err)
+}
+
+func (c *testClient) recv() string {
+	c.t.Helper()
+	require.True(c.t, c.lines.Scan(), "expected a response")
+	return c.lines.Text()
+}
+
+func (c *testClient) close() error {
+	c.w.Close()
+	return <-c.done
+}
+
+func TestServe(t *testing.T) {
+	s := NewServer()
+	s.Handle("echo", func(ctx context.Context, params json.RawMessage) (any, error) {
+		return params, nil
+	})
+	s.Handle("ping", func(context.Context, json.RawMessage) (any, error) {
+		return nil, nil
+	})
+	s.Handle("fail", func(context.Context, json.RawMessage) (any, error) {
+		return nil, errors.New("boom")
+	})
+	s.Handle("panic", func(context.Context, json.RawMessage) (any, error) {
+		panic("secret state")
+	})
+	s.Handle("unencodable", func(context.Context, json.RawMessage) (any, error) {
+		return func() {}, nil
+	})
+	s.Handle("teapot", func(context.Context, json.RawMessage) (any, error) {
+		return nil, &Error{Code: 418, Message: "teapot", Data: "short and stout"}
+	})
+	c := startServer(t, s)
+
+	tests := []struct {
+		name string
+		req  string
+		want string
+	}{
+		{"result", `{"jsonrpc":"2.0","id":1,"method":"echo","params":{"a":1}}`,
+			`{"jsonrpc":"2.0","id":1,"result":{"a":1}}`},
+		{"nil result", `{"jsonrpc":"2.0","id":"p","method":"ping"}`,
+			`{"jsonrpc":"2.0","id":"p","result":{}}`},
+		{"handler error", `{"jsonrpc":"2.0","id":2,"method":"fail"}`,
+			`{"jsonrpc":"2.0","id":2,"error":{"code":-32603,"message":"internal error"}}`},
+		{"panic", `{"jsonrpc":"2.0","id":"x","method":"panic"}`,
+			`{"jsonrpc":"2.0","id":"x","error":{"code":-32603,"message":"internal error"}}`},
+		{"unencodable result", `{"jsonrpc":"2.0","id":"y","method":"unencodable"}`,
+			`{"jsonrpc":"2.0","id":"y","error":{"code":-32603,"message":"internal error"}}`},
+		{"custom error", `{"jsonrpc":"2.0","id":3,"method":"teapot"}`,
+			`{"jsonrpc":"2.0","id":3,"error":{"code":418,"message":"teapot","data":"short and stout"}}`},
+		{"unknown method", `{"jsonrpc":"2.0","id":4,"method":"nope"}`,
+			`{"jsonrpc":"2.0","id":4,"error":{"code":-32601,"message":"method not found: nope"}}`},
+		{"wrong version", `{"jsonrpc":"1.0","id":5,"method":"echo"}`,
+			`{"jsonrpc":"2.0","id":5,"error":{"code":-32600,"message":"invalid request"}}`},
+		{"batch", `[{"jsonrpc":"2.0","id":6,"method":"ping"},{"jsonrpc":"2.0","method":"ping"},7]`,
+			`[{"jsonrpc":"2.0","id":6,"result":{}},{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"invalid request"}}]`},
+		{"empty batch", `[]`,
+			`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"empty batch"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.send(tt.req)
+			assert.JSONEq(t, tt.want, c.recv())
+		})
+	}
+
+	// Notifications get no response, so the next line answers the request.
+	c.send(`{"jsonrpc":"2.0","method":"echo"}`)
+	c.send(`{"jsonrpc":"2.0","method":"nope"}`)
+	c.send(`{"jsonrpc":"2.0","id":8,"method":"ping"}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":8,"result":{}}`, c.recv())
+
+	c.send(`{"jsonrpc":`)
+	var resp Response
+	require.NoError(t, json.Unmarshal([]byte(c.recv()), &resp))
+	assert.Equal(t, CodeParseError, resp.Error.Code)
+	assert.Equal(t, "null", string(resp.ID))
+
+	assert.NoError(t, c.close())
+}
+
+func TestServe_WaitsForPendingRequests(t *testing.T) {
+	release := make(chan struct{})
+	s := NewServer()
+	s.Handle("slow", func(context.Context, json.RawMessage) (any, error) {
+		<-release
+		return "done", nil
+	})
+	c := startServer(t, s)
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"slow"}`)
+	closed := make(chan error, 1)
+	go func() { closed <- c.close() }()
+	close(release)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":"done"}`, c.recv())
+	assert.NoError(t, <-closed)
+}
+
+func TestServe_MaxMessageSize(t *testing.T) {
+	s := NewServer()
+	s.MaxMessageSize = 64
+	s.Handle("ping", func(context.Context, json.RawMessage) (any, error) { return nil, nil })
+	c := startServer(t, s)
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"ping"}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, c.recv())
+
+	go io.WriteString(c.w, `{"jsonrpc":"2.0","id":2,"method":"ping","params":"`+strings.Repeat("x", 100)+"\"}\n")
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"message too large"}}`, c.recv())
+	assert.ErrorIs(t, <-c.done, bufio.ErrTooLong)
+}
+
+func TestServe_MaxConcurrent(t *testing.T) {
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	s := NewServer()
+	s.MaxConcurrent = 2
+	s.Handle("slow", func(context.Context, json.RawMessage) (any, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		return nil, nil
+	})
+	c := startServer(t, s)
+
+	// Serve stops reading at the limit, so the writes block.
+	go func() {
+		for i := range 5 {
+			io.WriteString(c.w, `{"jsonrpc":"2.0","id":`+strconv.Itoa(i)+`,"method":"slow"}`+"\n")
+		}
+	}()
+	require.Eventually(t, func() bool { return running.Load() == 2 }, time.Second, time.Millisecond)
+	close(release)
+	for range 5 {
+		c.recv()
+	}
+	assert.Equal(t, int32(2), peak.Load())
+	assert.NoError(t, c.close())
+}
+
+func TestCall(t *testing.T) {
+	s := NewServer()
+	assert.Nil(t, s.Call(context.Background(), &Request{JSONRPC: "2.0", Method: "nope"}))
+
+	resp := s.Call(context.Background(), &Request{JSONRPC: "2.0", ID: json.RawMessage("1")})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeInvalidRequest, resp.Error.Code)
+	assert.EqualError(t, resp.Error, "jsonrpc error -32600: invalid request")
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	mcpui "github.com/ironystock/mcpui-go"
+)
+
+// MCP resources methods registered by [RegisterResources].
+const (
+	MethodResourcesList          = "resources/list"
+	MethodResourcesRead          = "resources/read"
+	MethodResourcesTemplatesList = "resources/templates/list"
+	MethodResourcesSubscribe     = "resources/subscribe"
+	MethodResourcesUnsubscribe   = "resources/unsubscribe"
+)
+
+// ResourceSource lists and reads UI resources. *mcpui.ResourceRegistry
+// implements it.
+type ResourceSource interface {
+	List(ctx context.Context, cursor string) (*mcpui.ListUIResourcesResult, error)
+	Read(ctx context.Context, uri string) (*mcpui.ReadUIResourceResult, error)
+}
+
+// TemplateSource lists UI resource templates. *mcpui.ResourceRegistry
+// implements it.
+type TemplateSource interface {
+	ListTemplates(ctx context.Context, cursor string) (*mcpui.ListUIResourceTemplatesResult, error)
+}
+
+// SubscriptionSource records resource subscriptions of sessions.
+// *mcpui.ResourceRegistry implements it.
+type SubscriptionSource interface {
+	Subscribe(uri, sessionID string) error
+	Unsubscribe(uri, sessionID string) bool
+	RemoveSession(sessionID string)
+}
+
+// ListParams are the parameters of the list methods.
+type ListParams struct {
+	// Cursor is the NextCursor of the previous page, or empty.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ReadParams are the parameters of resources/read, resources/subscribe and
+// resources/unsubscribe.
+type ReadParams struct {
+	// URI is the URI of the resource to read.
+	URI string `json:"uri"`
+}
+
+// RegisterResources registers resources/list, resources/read and
+// resources/templates/list on s. If src does not implement
+// [TemplateSource], resources/templates/list returns an empty list. If src
+// implements [SubscriptionSource], resources/subscribe and
+// resources/unsubscribe are registered too, subscribing the session the
+// request arrived on, and a session's subscriptions are removed when its
+// connection closes. Pass s to the source's notifier setter, such as
+// [mcpui.ResourceRegistry.SetNotifier], to deliver the notifications.
+//
+// Reading or subscribing to an unknown resource fails with
+// [CodeResourceNotFound] and an invalid cursor with [CodeInvalidParams].
+func RegisterResources(s *Server, src ResourceSource) {
+	s.Handle(MethodResourcesList, func(ctx context.Context, params json.RawMessage) (any, error) {
+		var p ListParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return src.List(ctx, p.Cursor)
+	})
+
+	s.Handle(MethodResourcesRead, func(ctx context.Context, params json.RawMessage) (any, error) {
+		var p ReadParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.URI == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "uri is required"}
+		}
+		result, err := src.Read(ctx, p.URI)
+		if err != nil {
+			return nil, resourceError(err, p.URI)
+		}
+		return result, nil
+	})
+
+	s.Handle(MethodResourcesTemplatesList, func(ctx context.Context, params json.RawMessage) (any, error) {
+		var p ListParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if ts, ok := src.(TemplateSource); ok {
+			return ts.ListTemplates(ctx, p.Cursor)
+		}
+		return &mcpui.ListUIResourceTemplatesResult{ResourceTemplates: []*mcpui.UIResourceTemplate{}}, nil
+	})
+
+	subs, ok := src.(SubscriptionSource)
+	if !ok {
+		return
+	}
+	s.Handle(MethodResourcesSubscribe, func(ctx context.Context, params json.RawMessage) (any, error) {
+		uri, sessionID, err := subscriptionParams(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if err := subs.Subscribe(uri, sessionID); err != nil {
+			return nil, resourceError(err, uri)
+		}
+		return nil, nil
+	})
+	s.Handle(MethodResourcesUnsubscribe, func(ctx context.Context, params json.RawMessage) (any, error) {
+		uri, sessionID, err := subscriptionParams(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		subs.Unsubscribe(uri, sessionID)
+		return nil, nil
+	})
+	s.OnSessionClose(subs.RemoveSession)
+}
+
+// subscriptionParams returns the URI and session of a subscription request.
+func subscriptionParams(ctx context.Context, params json.RawMessage) (uri, sessionID string, err error) {
+	var p ReadParams
+	if err := decodeParams(params, &p); err != nil {
+		return "", "", err
+	}
+	if p.URI == "" {
+		return "", "", &Error{Code: CodeInvalidParams, Message: "uri is required"}
+	}
+	sessionID = SessionID(ctx)
+	if sessionID == "" {
+		return "", "", &Error{Code: CodeInvalidRequest, Message: "subscriptions require a session"}
+	}
+	return p.URI, sessionID, nil
+}
+
+// resourceError adds the URI to resource-not-found errors.
+func resourceError(err error, uri string) error {
+	if rpcErr := *toError(err); rpcErr.Code == CodeResourceNotFound {
+		rpcErr.Data = map[string]string{"uri": uri}
+		return &rpcErr
+	}
+	return err
+}
+
+// decodeParams decodes optional params into v.
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
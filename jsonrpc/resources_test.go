@@ -0,0 +1,173 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	mcpui "github.com/ironystock/mcpui-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistry(t *testing.T) *mcpui.ResourceRegistry {
+	t.Helper()
+	r := mcpui.NewResourceRegistry()
+	r.SetPageSize(1)
+	require.NoError(t, r.Register(&mcpui.UIResource{URI: "ui://a", Name: "a"}, &mcpui.HTMLContent{HTML: "<p>a</p>"}))
+	require.NoError(t, r.Register(&mcpui.UIResource{URI: "ui://b", Name: "b"}, &mcpui.URLContent{URL: "https://example.com"}))
+	require.NoError(t, r.RegisterTemplate(&mcpui.UIResourceTemplate{URITemplate: "ui://orders/{id}", Name: "order"},
+		func(ctx context.Context, uri string, vars map[string]string) (mcpui.UIContent, error) {
+			return &mcpui.HTMLContent{HTML: "<p>order " + vars["id"] + "</p>"}, nil
+		}))
+	return r
+}
+
+func TestRegisterResources(t *testing.T) {
+	s := NewServer()
+	RegisterResources(s, testRegistry(t))
+	c := startServer(t, s)
+
+	call := func(req string) *Response {
+		t.Helper()
+		c.send(req)
+		var resp Response
+		require.NoError(t, json.Unmarshal([]byte(c.recv()), &resp))
+		return &resp
+	}
+
+	// Page through resources/list.
+	resp := call(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)
+	require.Nil(t, resp.Error)
+	var list mcpui.ListUIResourcesResult
+	require.NoError(t, json.Unmarshal(resp.Result, &list))
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "ui://a", list.Resources[0].URI)
+	require.NotEmpty(t, list.NextCursor)
+
+	params, _ := json.Marshal(ListParams{Cursor: list.NextCursor})
+	resp = call(`{"jsonrpc":"2.0","id":2,"method":"resources/list","params":` + string(params) + `}`)
+	require.Nil(t, resp.Error)
+	list = mcpui.ListUIResourcesResult{}
+	require.NoError(t, json.Unmarshal(resp.Result, &list))
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "ui://b", list.Resources[0].URI)
+	assert.Empty(t, list.NextCursor)
+
+	resp = call(`{"jsonrpc":"2.0","id":3,"method":"resources/list","params":{"cursor":"forged"}}`)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeInvalidParams, resp.Error.Code)
+
+	// resources/read, including templates.
+	resp = call(`{"jsonrpc":"2.0","id":4,"method":"resources/read","params":{"uri":"ui://orders/42"}}`)
+	require.Nil(t, resp.Error)
+	assert.JSONEq(t, `{"contents":[{"uri":"ui://orders/42","mimeType":"text/html","text":"<p>order 42</p>"}]}`, string(resp.Result))
+
+	resp = call(`{"jsonrpc":"2.0","id":5,"method":"resources/read","params":{"uri":"ui://missing"}}`)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeResourceNotFound, resp.Error.Code)
+	assert.Equal(t, map[string]any{"uri": "ui://missing"}, resp.Error.Data)
+
+	resp = call(`{"jsonrpc":"2.0","id":6,"method":"resources/read","params":{}}`)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeInvalidParams, resp.Error.Code)
+
+	resp = call(`{"jsonrpc":"2.0","id":7,"method":"resources/read","params":"ui://a"}`)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeInvalidParams, resp.Error.Code)
+
+	// resources/templates/list.
+	resp = call(`{"jsonrpc":"2.0","id":8,"method":"resources/templates/list","params":{}}`)
+	require.Nil(t, resp.Error)
+	assert.JSONEq(t, `{"resourceTemplates":[{"uriTemplate":"ui://orders/{id}","name":"order"}]}`, string(resp.Result))
+
+	assert.NoError(t, c.close())
+}
+
+// listOnly is a ResourceSource without templates.
+type listOnly struct{ r *mcpui.ResourceRegistry }
+
+func (l listOnly) List(ctx context.Context, cursor string) (*mcpui.ListUIResourcesResult, error) {
+	return l.r.List(ctx, cursor)
+}
+
+func (l listOnly) Read(ctx context.Context, uri string) (*mcpui.ReadUIResourceResult, error) {
+	return l.r.Read(ctx, uri)
+}
+
+func TestRegisterResources_WithoutTemplates(t *testing.T) {
+	s := NewServer()
+	RegisterResources(s, listOnly{testRegistry(t)})
+
+	resp := s.Call(context.Background(), &Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: MethodResourcesTemplatesList})
+	require.Nil(t, resp.Error)
+	assert.JSONEq(t, `{"resourceTemplates":[]}`, string(resp.Result))
+}
+
+func TestRegisterResources_FSProvider(t *testing.T) {
+	p, err := mcpui.NewFSProvider(fstest.MapFS{"page.html": {Data: []byte("<p>hi</p>")}}, "ui://app/")
+	require.NoError(t, err)
+	for _, src := range []ResourceSource{p, mcpui.NewResourceWatcher(p)} {
+		s := NewServer()
+		RegisterResources(s, src)
+
+		resp := s.Call(context.Background(), &Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: MethodResourcesList})
+		require.Nil(t, resp.Error)
+		assert.JSONEq(t, `{"resources":[{"uri":"ui://app/page","name":"page","mimeType":"text/html"}]}`, string(resp.Result))
+
+		resp = s.Call(context.Background(), &Request{JSONRPC: "2.0", ID: json.RawMessage("2"), Method: MethodResourcesList, Params: json.RawMessage(`{"cursor":"x"}`)})
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, CodeInvalidParams, resp.Error.Code)
+	}
+}
+
+func TestRegisterResources_Subscriptions(t *testing.T) {
+	registry := testRegistry(t)
+	s := NewServer()
+	RegisterResources(s, registry)
+	registry.SetNotifier(s)
+	c := startServer(t, s)
+	ctx := context.Background()
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"ui://a"}}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, c.recv())
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"ui://orders/7"}}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"result":{}}`, c.recv())
+	c.send(`{"jsonrpc":"2.0","id":3,"method":"resources/subscribe","params":{"uri":"ui://missing"}}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":3,"error":{"code":-32002,"message":"resource not found: ui://missing","data":{"uri":"ui://missing"}}}`, c.recv())
+	c.send(`{"jsonrpc":"2.0","id":4,"method":"resources/subscribe","params":{}}`)
+	assert.Contains(t, c.recv(), `"code":-32602`)
+
+	// Pipes block writes until they are read, so notify in the background.
+	notified := make(chan error, 2)
+	go func() {
+		notified <- registry.NotifyUpdated(ctx, "ui://a")
+		notified <- registry.NotifyListChanged(ctx)
+	}()
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":"ui://a"}}`, c.recv())
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/resources/list_changed"}`, c.recv())
+	require.NoError(t, <-notified)
+	require.NoError(t, <-notified)
+
+	c.send(`{"jsonrpc":"2.0","id":5,"method":"resources/unsubscribe","params":{"uri":"ui://a"}}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":5,"result":{}}`, c.recv())
+	assert.Equal(t, []string{"ui://orders/7"}, registry.Subscriptions().Subscriptions("1"))
+
+	assert.NoError(t, c.close())
+	assert.Empty(t, registry.Subscriptions().Subscriptions("1"), "subscriptions end with the connection")
+	assert.Error(t, s.Notify(ctx, "1", mcpui.NewResourceListChangedNotification()))
+
+	resp := s.Call(ctx, &Request{JSONRPC: "2.0", ID: json.RawMessage("6"), Method: MethodResourcesSubscribe, Params: json.RawMessage(`{"uri":"ui://a"}`)})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, CodeInvalidRequest, resp.Error.Code, "calls outside Serve have no session")
+
+	other := NewServer()
+	RegisterResources(other, listOnly{registry})
+	resp = other.Call(ctx, &Request{JSONRPC: "2.0", ID: json.RawMessage("7"), Method: MethodResourcesSubscribe})
+	assert.Equal(t, CodeMethodNotFound, resp.Error.Code, "sources without subscriptions")
+}
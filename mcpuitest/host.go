@@ -0,0 +1,316 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package mcpuitest provides test doubles for code built on mcpui.
+//
+// A [FakeHost] plays the part of an MCP-UI host and its iframe: it renders
+// a UI resource, sends actions to an [mcpui.ActionDispatcher] through typed
+// helpers, and records every response. A [Router] wraps an [mcpui.Router]
+// and records the requests it dispatches:
+//
+//	func TestRefresh(t *testing.T) {
+//		router := mcpuitest.NewRouter()
+//		router.Respond(mcpui.ActionTypeTool, map[string]any{"ok": true})
+//
+//		host := mcpuitest.NewFakeHost(t, router)
+//		host.Render(dashboardContents)
+//		host.CallTool("refresh", nil)
+//		host.ExpectSuccess()
+//
+//		if n := router.ToolCallCount("refresh"); n != 1 {
+//			t.Errorf("refresh called %d times, want 1", n)
+//		}
+//	}
+//
+// The package only depends on the standard library and reports failures
+// through [testing.TB].
+package mcpuitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	mcpui "github.com/ironystock/mcpui-go"
+)
+
+// FakeHost is an MCP-UI host for tests. Actions are served by an
+// [mcpui.ActionHTTPHandler], so they are validated, dispatched and answered
+// exactly as over HTTP, and responses are decoded from JSON as a real host
+// sees them. It is safe for concurrent use.
+type FakeHost struct {
+	// Session is passed to handlers as [mcpui.UIActionRequest].Session.
+	// Set it before sending actions.
+	Session any
+
+	t       testing.TB
+	handler *mcpui.ActionHTTPHandler
+
+	mu        sync.Mutex
+	rendered  *mcpui.UIResourceContents
+	content   mcpui.UIContent
+	actions   []*mcpui.UIAction
+	responses []*mcpui.UIResponse
+	nextID    int
+}
+
+// NewFakeHost creates a host whose actions are dispatched to d.
+func NewFakeHost(t testing.TB, d mcpui.ActionDispatcher) *FakeHost {
+	h := &FakeHost{t: t}
+	h.handler = mcpui.HTTPHandler(d)
+	h.handler.SessionExtractor = func(*http.Request) (any, error) {
+		return h.Session, nil
+	}
+	return h
+}
+
+// Render decodes rc as a host would before displaying it and makes it the
+// resource that sends the following actions. The test fails immediately if
+// rc is not valid UI content.
+func (h *FakeHost) Render(rc *mcpui.UIResourceContents) mcpui.UIContent {
+	h.t.Helper()
+	if rc == nil {
+		h.t.Fatalf("mcpuitest: rendering nil resource contents")
+	}
+	content, err := rc.ToUIContent()
+	if err != nil {
+		h.t.Fatalf("mcpuitest: rendering %s: %v", rc.URI, err)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rendered = rc
+	h.content = content
+	return content
+}
+
+// Rendered returns the resource passed to the last Render call, or nil.
+func (h *FakeHost) Rendered() *mcpui.UIResourceContents {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.rendered
+}
+
+// Content returns the content decoded by the last Render call, or nil.
+func (h *FakeHost) Content() mcpui.UIContent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.content
+}
+
+// CallTool sends a tool action and returns the response.
+func (h *FakeHost) CallTool(name string, params map[string]any) *mcpui.UIResponse {
+	h.t.Helper()
+	action, err := mcpui.NewToolAction("", name, params)
+	return h.send(action, err)
+}
+
+// Intent sends an intent action and returns the response.
+func (h *FakeHost) Intent(intent string, params map[string]any) *mcpui.UIResponse {
+	h.t.Helper()
+	action, err := mcpui.NewIntentAction("", intent, params)
+	return h.send(action, err)
+}
+
+// Prompt sends a prompt action and returns the response.
+func (h *FakeHost) Prompt(prompt string) *mcpui.UIResponse {
+	h.t.Helper()
+	action, err := mcpui.NewPromptAction("", prompt)
+	return h.send(action, err)
+}
+
+// Notify sends a notify action and returns the response.
+func (h *FakeHost) Notify(message, level string) *mcpui.UIResponse {
+	h.t.Helper()
+	action, err := mcpui.NewNotifyAction(message, level)
+	return h.send(action, err)
+}
+
+// Link sends a link action and returns the response. The test fails
+// immediately if rawURL is rejected by [mcpui.NewLinkAction]; use Send to
+// test handlers against invalid links.
+func (h *FakeHost) Link(rawURL string) *mcpui.UIResponse {
+	h.t.Helper()
+	action, err := mcpui.NewLinkAction(rawURL)
+	return h.send(action, err)
+}
+
+// Resize sends a ui-size-change action and returns the response.
+func (h *FakeHost) Resize(height, width int) *mcpui.UIResponse {
+	h.t.Helper()
+	action, err := mcpui.NewUISizeAction(height, width)
+	return h.send(action, err)
+}
+
+// Send sends any action and returns the response. A message ID is assigned
+// if the action has none; action itself is not modified.
+func (h *FakeHost) Send(action *mcpui.UIAction) *mcpui.UIResponse {
+	h.t.Helper()
+	return h.send(action, nil)
+}
+
+func (h *FakeHost) send(action *mcpui.UIAction, err error) *mcpui.UIResponse {
+	h.t.Helper()
+	if err != nil {
+		h.t.Fatalf("mcpuitest: building action: %v", err)
+	}
+	if action == nil {
+		h.t.Fatalf("mcpuitest: sending nil action")
+	}
+
+	a := *action
+	h.mu.Lock()
+	if a.MessageID == "" {
+		h.nextID++
+		a.MessageID = fmt.Sprintf("msg-%d", h.nextID)
+	}
+	var resourceURI string
+	if h.rendered != nil {
+		resourceURI = h.rendered.URI
+	}
+	h.mu.Unlock()
+
+	body, err := json.Marshal(&a)
+	if err != nil {
+		h.t.Fatalf("mcpuitest: encoding action: %v", err)
+	}
+	req := httptest.NewRequestWithContext(h.t.Context(), http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if resourceURI != "" {
+		req.Header.Set(mcpui.HeaderResourceURI, resourceURI)
+	}
+	rec := httptest.NewRecorder()
+	h.handler.ServeHTTP(rec, req)
+
+	var resp mcpui.UIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		h.t.Fatalf("mcpuitest: decoding response (status %d): %v", rec.Code, err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.actions = append(h.actions, &a)
+	h.responses = append(h.responses, &resp)
+	return &resp
+}
+
+// Actions returns the actions sent so far, in order.
+func (h *FakeHost) Actions() []*mcpui.UIAction {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*mcpui.UIAction(nil), h.actions...)
+}
+
+// Responses returns the responses received so far, in order.
+func (h *FakeHost) Responses() []*mcpui.UIResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*mcpui.UIResponse(nil), h.responses...)
+}
+
+// LastResponse returns the most recent response, or nil.
+func (h *FakeHost) LastResponse() *mcpui.UIResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.responses) == 0 {
+		return nil
+	}
+	return h.responses[len(h.responses)-1]
+}
+
+// Reset forgets the recorded actions and responses. The rendered resource
+// is kept.
+func (h *FakeHost) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.actions = nil
+	h.responses = nil
+}
+
+// ExpectToolCalled reports a test error unless a tool action named name
+// was sent and reached a handler. Actions rejected as invalid or without a
+// handler do not count; a handler that returned an error does. It returns
+// the payload of the last such action, or nil.
+func (h *FakeHost) ExpectToolCalled(name string) *mcpui.ToolActionPayload {
+	h.t.Helper()
+	h.mu.Lock()
+	actions := slices.Clone(h.actions)
+	responses := slices.Clone(h.responses)
+	h.mu.Unlock()
+
+	var rejected *mcpui.ResponseError
+	for i := len(actions) - 1; i >= 0; i-- {
+		if actions[i].Type != mcpui.ActionTypeTool {
+			continue
+		}
+		p, err := actions[i].ToolPayload()
+		if err != nil || p.ToolName != name {
+			continue
+		}
+		if e := responses[i].GetError(); e != nil && (e.Code == mcpui.ErrorCodeNoHandler || e.Code == mcpui.ErrorCodeInvalidRequest) {
+			if rejected == nil {
+				rejected = e
+			}
+			continue
+		}
+		return p
+	}
+	if rejected != nil {
+		h.t.Errorf("mcpuitest: tool %q was sent but not handled, got error %q: %s", name, rejected.Code, rejected.Message)
+	} else {
+		h.t.Errorf("mcpuitest: tool %q was not called", name)
+	}
+	return nil
+}
+
+// ExpectError reports a test error unless the last response is an error
+// with the given code, such as mcpui.ErrorCodeNoHandler. An empty code
+// accepts any error. It returns the error, or nil.
+func (h *FakeHost) ExpectError(code string) *mcpui.ResponseError {
+	h.t.Helper()
+	resp := h.LastResponse()
+	if resp == nil {
+		h.t.Errorf("mcpuitest: expected error %q, got no response", code)
+		return nil
+	}
+	e := resp.GetError()
+	switch {
+	case e == nil:
+		h.t.Errorf("mcpuitest: expected error %q, got success: %s", code, describe(resp.GetResponse()))
+		return nil
+	case code != "" && e.Code != code:
+		h.t.Errorf("mcpuitest: expected error %q, got %q: %s", code, e.Code, e.Message)
+	}
+	return e
+}
+
+// ExpectSuccess reports a test error unless the last response is a
+// success. It returns the response data, decoded from JSON.
+func (h *FakeHost) ExpectSuccess() any {
+	h.t.Helper()
+	resp := h.LastResponse()
+	switch {
+	case resp == nil:
+		h.t.Errorf("mcpuitest: expected success, got no response")
+		return nil
+	case resp.IsError():
+		e := resp.GetError()
+		h.t.Errorf("mcpuitest: expected success, got error %q: %s", e.Code, e.Message)
+		return nil
+	}
+	return resp.GetResponse()
+}
+
+// describe formats v for failure messages.
+func describe(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpuitest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	mcpui "github.com/ironystock/mcpui-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTB records failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	mu       sync.Mutex
+	failures []string
+	fatal    bool
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.mu.Lock()
+	r.fatal = true
+	r.mu.Unlock()
+	runtime.Goexit()
+}
+
+// run calls f in its own goroutine so that Fatalf can stop it.
+func (r *recordingTB) run(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	<-done
+}
+
+func testContents(t *testing.T) *mcpui.UIResourceContents {
+	rc, err := mcpui.NewUIResourceContents("ui://dashboard/main", &mcpui.HTMLContent{HTML: "<p>Dashboard</p>"})
+	require.NoError(t, err)
+	return rc
+}
+
+func TestFakeHost_Actions(t *testing.T) {
+	router := NewRouter()
+	router.HandleType(mcpui.ActionTypeTool, mcpui.WrapToolHandler(func(ctx context.Context, name string, params map[string]any) (any, error) {
+		return map[string]any{"tool": name, "params": params}, nil
+	}))
+	router.HandleType(mcpui.ActionTypeNotify, func(ctx context.Context, req *mcpui.UIActionRequest) (*mcpui.UIActionResult, error) {
+		return &mcpui.UIActionResult{Response: map[string]any{"session": req.Session, "resource": req.ResourceURI}}, nil
+	})
+	router.Respond(mcpui.ActionTypeIntent, "intent ok")
+	router.Respond(mcpui.ActionTypePrompt, "prompt ok")
+	router.Respond(mcpui.ActionTypeLink, "link ok")
+	router.Respond(mcpui.ActionTypeUISize, "size ok")
+
+	host := NewFakeHost(t, router)
+	host.Session = "s1"
+	content := host.Render(testContents(t))
+	assert.Equal(t, &mcpui.HTMLContent{HTML: "<p>Dashboard</p>"}, content)
+	assert.Equal(t, content, host.Content())
+	assert.Equal(t, "ui://dashboard/main", host.Rendered().URI)
+
+	resp := host.CallTool("refresh", map[string]any{"id": 1})
+	assert.Equal(t, mcpui.ResponseTypeResponse, resp.Type)
+	assert.Equal(t, "msg-1", resp.MessageID)
+	assert.Equal(t, map[string]any{"tool": "refresh", "params": map[string]any{"id": float64(1)}}, host.ExpectSuccess(),
+		"responses are decoded from JSON")
+
+	assert.Equal(t, map[string]any{"session": "s1", "resource": "ui://dashboard/main"}, host.Notify("hi", "info").GetResponse())
+	assert.Equal(t, "intent ok", host.Intent("open", nil).GetResponse())
+	assert.Equal(t, "prompt ok", host.Prompt("summarize").GetResponse())
+	assert.Equal(t, "link ok", host.Link("https://example.com").GetResponse())
+	assert.Equal(t, "size ok", host.Resize(400, 300).GetResponse())
+
+	action, err := mcpui.NewToolAction("custom-id", "refresh", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "custom-id", host.Send(action).MessageID)
+
+	assert.Len(t, host.Actions(), 7)
+	assert.Len(t, host.Responses(), 7)
+	assert.Equal(t, "msg-6", host.Responses()[5].MessageID)
+	assert.Nil(t, host.ExpectToolCalled("refresh").Params, "the payload of the last call is returned")
+
+	assert.Equal(t, 2, router.ToolCallCount("refresh"))
+	assert.Equal(t, 2, router.CallCount(mcpui.ActionTypeTool))
+	assert.Equal(t, 7, router.CallCount(""))
+	assert.Equal(t, "s1", router.Calls()[0].Session)
+
+	host.Reset()
+	router.Reset()
+	assert.Empty(t, host.Actions())
+	assert.Nil(t, host.LastResponse())
+	assert.Empty(t, router.Calls())
+	assert.NotNil(t, host.Rendered(), "Reset keeps the rendered resource")
+}
+
+func TestFakeHost_Errors(t *testing.T) {
+	router := NewRouter()
+	router.Fail(mcpui.ActionTypeTool, errors.New("database down"))
+	router.Fail(mcpui.ActionTypeNotify, &mcpui.PublicError{Err: errors.New("notifications paused")})
+	router.Fail(mcpui.ActionTypePrompt, fmt.Errorf("%w: prompts disabled", mcpui.ErrNoHandler))
+	host := NewFakeHost(t, router)
+
+	host.CallTool("refresh", nil)
+	assert.Equal(t, "internal error", host.ExpectError(mcpui.ErrorCodeInternal).Message, "handler errors do not leak")
+
+	host.Notify("hi", "info")
+	assert.Equal(t, "notifications paused", host.ExpectError(mcpui.ErrorCodeInternal).Message)
+
+	host.Prompt("x")
+	host.ExpectError(mcpui.ErrorCodeNoHandler)
+
+	host.Intent("unhandled", nil)
+	host.ExpectError(mcpui.ErrorCodeNoHandler)
+	host.ExpectError("")
+
+	host.Send(&mcpui.UIAction{Payload: []byte(`{}`)})
+	host.ExpectError(mcpui.ErrorCodeInvalidRequest)
+
+	assert.NotNil(t, host.ExpectToolCalled("refresh"), "tools whose handler failed were called")
+
+	assert.Equal(t, 4, router.CallCount(""), "invalid actions are rejected before dispatch")
+	assert.Equal(t, 1, router.CallCount(mcpui.ActionTypeIntent), "actions without a handler are counted")
+}
+
+func TestFakeHost_AssertionFailures(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	router := NewRouter()
+	router.Respond(mcpui.ActionTypeTool, "ok")
+	host := NewFakeHost(tb, router)
+
+	assert.Nil(t, host.ExpectSuccess())
+	assert.Nil(t, host.ExpectError("x"))
+
+	host.CallTool("refresh", nil)
+	assert.Nil(t, host.ExpectToolCalled("other"))
+	assert.Nil(t, host.ExpectError(mcpui.ErrorCodeInternal))
+
+	host.Intent("unhandled", nil)
+	assert.Nil(t, host.ExpectSuccess())
+	assert.NotNil(t, host.ExpectError(mcpui.ErrorCodeInternal))
+
+	assert.Equal(t, []string{
+		"mcpuitest: expected success, got no response",
+		`mcpuitest: expected error "x", got no response`,
+		`mcpuitest: tool "other" was not called`,
+		`mcpuitest: expected error "internal_error", got success: "ok"`,
+		`mcpuitest: expected success, got error "no_handler": no handler for action type "intent" from resource ""`,
+		`mcpuitest: expected error "internal_error", got "no_handler": no handler for action type "intent" from resource ""`,
+	}, tb.failures)
+	assert.False(t, tb.fatal)
+}
+
+func TestFakeHost_ExpectToolCalledNoHandler(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	router := NewRouter()
+	host := NewFakeHost(tb, router)
+
+	host.CallTool("refresh", nil)
+	host.ExpectError(mcpui.ErrorCodeNoHandler)
+	assert.Nil(t, host.ExpectToolCalled("refresh"))
+	assert.Equal(t, []string{
+		`mcpuitest: tool "refresh" was sent but not handled, got error "no_handler": no handler for action type "tool" from resource ""`,
+	}, tb.failures)
+
+	router.Respond(mcpui.ActionTypeTool, "ok")
+	host.CallTool("refresh", nil)
+	assert.NotNil(t, host.ExpectToolCalled("refresh"), "a later handled call counts")
+	assert.Len(t, tb.failures, 1)
+}
+
+func TestFakeHost_Fatal(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(*FakeHost)
+	}{
+		{"invalid link", func(h *FakeHost) { h.Link("javascript:alert(1)") }},
+		{"nil action", func(h *FakeHost) { h.Send(nil) }},
+		{"nil resource", func(h *FakeHost) { h.Render(nil) }},
+		{"invalid resource", func(h *FakeHost) { h.Render(&mcpui.UIResourceContents{URI: "ui://x", MIMEType: "text/plain"}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			host := NewFakeHost(tb, NewRouter())
+			tb.run(func() { tt.f(host) })
+			assert.True(t, tb.fatal)
+			assert.Len(t, tb.failures, 1)
+			assert.Empty(t, host.Actions())
+		})
+	}
+}
+
+func TestFakeHost_Concurrent(t *testing.T) {
+	router := NewRouter()
+	router.Respond(mcpui.ActionTypeTool, "ok")
+	host := NewFakeHost(t, router)
+	host.Render(testContents(t))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			host.CallTool("refresh", nil)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, router.ToolCallCount("refresh"))
+	ids := make(map[string]bool)
+	for _, resp := range host.Responses() {
+		ids[resp.MessageID] = true
+	}
+	assert.Len(t, ids, 10, "message IDs are unique")
+}
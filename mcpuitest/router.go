@@ -0,0 +1,99 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpuitest
+
+import (
+	"context"
+	"sync"
+
+	mcpui "github.com/ironystock/mcpui-go"
+)
+
+// Router is an [mcpui.ActionDispatcher] for tests. It routes actions like
+// [mcpui.Router], whose registration methods it shares, and records every
+// request it dispatches, including those without a handler. Respond and
+// Fail register canned handlers. It is safe for concurrent use.
+type Router struct {
+	*mcpui.Router
+
+	mu    sync.Mutex
+	calls []*mcpui.UIActionRequest
+}
+
+// NewRouter creates a Router without handlers.
+func NewRouter() *Router {
+	return &Router{Router: mcpui.NewRouter()}
+}
+
+// Respond makes actions of the given type succeed with response.
+func (r *Router) Respond(actionType string, response any) {
+	r.HandleType(actionType, func(context.Context, *mcpui.UIActionRequest) (*mcpui.UIActionResult, error) {
+		return &mcpui.UIActionResult{Response: response}, nil
+	})
+}
+
+// Fail makes actions of the given type fail with err, which hosts receive
+// as an [mcpui.ErrorCodeInternal] error, or [mcpui.ErrorCodeNoHandler] if
+// err wraps [mcpui.ErrNoHandler]. The error message is only sent if err is
+// an [mcpui.PublicError].
+func (r *Router) Fail(actionType string, err error) {
+	r.HandleType(actionType, func(context.Context, *mcpui.UIActionRequest) (*mcpui.UIActionResult, error) {
+		return nil, err
+	})
+}
+
+// Dispatch records req and routes it with the embedded [mcpui.Router].
+func (r *Router) Dispatch(ctx context.Context, req *mcpui.UIActionRequest) (*mcpui.UIActionResult, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, req)
+	r.mu.Unlock()
+	return r.Router.Dispatch(ctx, req)
+}
+
+// Handle is an alias for Dispatch.
+func (r *Router) Handle(ctx context.Context, req *mcpui.UIActionRequest) (*mcpui.UIActionResult, error) {
+	return r.Dispatch(ctx, req)
+}
+
+// Calls returns the dispatched requests, in order.
+func (r *Router) Calls() []*mcpui.UIActionRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*mcpui.UIActionRequest(nil), r.calls...)
+}
+
+// CallCount returns the number of dispatched actions of the given type, or
+// of all types if actionType is empty.
+func (r *Router) CallCount(actionType string) int {
+	n := 0
+	for _, req := range r.Calls() {
+		if actionType == "" || (req.Action != nil && req.Action.Type == actionType) {
+			n++
+		}
+	}
+	return n
+}
+
+// ToolCallCount returns the number of dispatched tool actions calling the
+// named tool.
+func (r *Router) ToolCallCount(name string) int {
+	n := 0
+	for _, req := range r.Calls() {
+		if req.Action == nil || req.Action.Type != mcpui.ActionTypeTool {
+			continue
+		}
+		if p, err := req.Action.ToolPayload(); err == nil && p.ToolName == name {
+			n++
+		}
+	}
+	return n
+}
+
+// Reset forgets the recorded requests. Handlers are kept.
+func (r *Router) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+)
+
+// MIME type parameter names used by UI content.
+const (
+	// MIMEParamCharset is the character set of textual content.
+	MIMEParamCharset = "charset"
+	// MIMEParamFramework selects the Remote DOM rendering framework.
+	MIMEParamFramework = "framework"
+	// MIMEParamProfile identifies a profile of a base MIME type.
+	MIMEParamProfile = "profile"
+)
+
+// MediaType is a parsed MIME type such as
+// "application/vnd.mcp-ui.remote-dom+javascript; framework=react".
+type MediaType struct {
+	// Type is the lower-cased "type/subtype" without parameters.
+	Type string
+	// Params holds the parameters, keyed by lower-cased name.
+	Params map[string]string
+}
+
+// ParseMediaType parses a MIME type with optional parameters as defined in
+// RFC 2045 and RFC 2231. Type and parameter names are case-insensitive,
+// parameter order is irrelevant, and values may be quoted.
+func ParseMediaType(s string) (MediaType, error) {
+	typ, params, err := mime.ParseMediaType(s)
+	if err != nil {
+		return MediaType{}, fmt.Errorf("invalid MIME type %q: %w", s, err)
+	}
+	return MediaType{Type: typ, Params: params}, nil
+}
+
+// Param returns the value of the named parameter, or "" if it is absent.
+func (m MediaType) Param(name string) string {
+	return m.Params[strings.ToLower(name)]
+}
+
+// String formats the media type in canonical form: lower-case type,
+// parameters sorted by name and separated by "; ", and values quoted only
+// when necessary. Empty parameter values are omitted.
+func (m MediaType) String() string {
+	params := make(map[string]string, len(m.Params))
+	for k, v := range m.Params {
+		if v != "" {
+			params[k] = v
+		}
+	}
+	if s := mime.FormatMediaType(m.Type, params); s != "" {
+		return s
+	}
+	return m.Type
+}
+
+// newMediaType builds a MediaType from a type, additional parameters and
+// alternating parameter names and values. Named parameters replace
+// additional ones of the same name, even when their value is empty.
+func newMediaType(typ string, extra map[string]string, kv ...string) MediaType {
+	m := MediaType{Type: typ, Params: make(map[string]string, len(extra)+len(kv)/2)}
+	for k, v := range extra {
+		m.Params[strings.ToLower(k)] = v
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		m.Params[kv[i]] = kv[i+1]
+	}
+	return m
+}
+
+// otherParams returns params without the named parameters, or nil if no
+// others remain.
+func otherParams(params map[string]string, names ...string) map[string]string {
+	var other map[string]string
+	for k, v := range params {
+		if slices.Contains(names, k) {
+			continue
+		}
+		if other == nil {
+			other = make(map[string]string)
+		}
+		other[k] = v
+	}
+	return other
+}
+
+// wireParams returns the parameters of a wire MIME type, or nil if it
+// cannot be parsed.
+func wireParams(mimeType string) map[string]string {
+	m, err := ParseMediaType(mimeType)
+	if err != nil {
+		return nil
+	}
+	return m.Params
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMediaType(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantType   string
+		wantParams map[string]string
+		wantErr    bool
+	}{
+		{
+			name:       "plain",
+			input:      "text/html",
+			wantType:   "text/html",
+			wantParams: map[string]string{},
+		},
+		{
+			name:       "upper case with charset",
+			input:      "Text/HTML; Charset=UTF-8",
+			wantType:   "text/html",
+			wantParams: map[string]string{"charset": "UTF-8"},
+		},
+		{
+			name:       "no space and quoted value",
+			input:      `application/vnd.mcp-ui.remote-dom+javascript;charset=utf-8;framework="react"`,
+			wantType:   "application/vnd.mcp-ui.remote-dom+javascript",
+			wantParams: map[string]string{"charset": "utf-8", "framework": "react"},
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			input:   "text/html; charset",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMediaType(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, got.Type)
+			assert.Equal(t, tt.wantParams, got.Params)
+		})
+	}
+}
+
+func TestMediaType_String(t *testing.T) {
+	m := MediaType{Type: "text/html", Params: map[string]string{"profile": "mcp-app", "charset": "utf-8", "empty": ""}}
+	assert.Equal(t, "text/html; charset=utf-8; profile=mcp-app", m.String())
+	assert.Equal(t, "utf-8", m.Param("Charset"))
+
+	assert.Equal(t, "text/html", MediaType{Type: "text/html"}.String())
+	assert.Equal(t, `text/plain; title="a b"`, MediaType{Type: "text/plain", Params: map[string]string{"title": "a b"}}.String())
+}
+
+func TestUIResourceContents_MediaType(t *testing.T) {
+	rc := &UIResourceContents{URI: "ui://x", MIMEType: "text/html; charset=utf-8"}
+	mt, err := rc.MediaType()
+	require.NoError(t, err)
+	assert.Equal(t, "text/html", mt.Type)
+	assert.Equal(t, "utf-8", mt.Param(MIMEParamCharset))
+}
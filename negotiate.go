@@ -0,0 +1,188 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CapabilityKey is the key under which clients advertise MCP-UI support,
+// in the experimental capabilities or the _meta of the initialize request.
+const CapabilityKey = "mcp-ui"
+
+// ClientCapabilities records which UI content a client can render. A nil
+// ClientCapabilities describes a client that did not advertise UI support
+// and only renders text. Clients advertise it when initializing:
+//
+//	{
+//	  "capabilities": {
+//	    "experimental": {
+//	      "mcp-ui": {"mimeTypes": ["text/html", "application/vnd.mcp-ui.remote-dom"], "frameworks": ["react"]}
+//	    }
+//	  }
+//	}
+type ClientCapabilities struct {
+	// MIMETypes lists the supported content types. Entries match content
+	// with the same type regardless of parameters, unless they give
+	// parameters of their own. The profile parameter is the exception:
+	// content with a profile, such as MCP Apps HTML, only matches entries
+	// naming that profile. "type/*" and "*/*" are wildcards, and
+	// "application/vnd.mcp-ui.remote-dom" also matches its +javascript
+	// variant. Empty means the default set, text/html and text/uri-list.
+	MIMETypes []string `json:"mimeTypes,omitempty"`
+	// Frameworks lists the supported Remote DOM frameworks. Empty means
+	// any framework.
+	Frameworks []Framework `json:"frameworks,omitempty"`
+}
+
+// ParseClientCapabilities extracts the UI capabilities from the params of
+// an MCP initialize request. Entries found under capabilities.experimental
+// and _meta, and the MCP Apps entry under capabilities.extensions, are
+// merged. An entry without MIME types stands for the default set, or for
+// MCP Apps HTML under the MCP Apps extension. It returns nil if the client
+// advertises none of them, meaning it only renders text.
+func ParseClientCapabilities(initializeParams json.RawMessage) (*ClientCapabilities, error) {
+	var params struct {
+		Capabilities struct {
+			Experimental map[string]json.RawMessage `json:"experimental"`
+			Extensions   map[string]json.RawMessage `json:"extensions"`
+		} `json:"capabilities"`
+		Meta map[string]json.RawMessage `json:"_meta"`
+	}
+	if err := json.Unmarshal(initializeParams, &params); err != nil {
+		return nil, fmt.Errorf("parsing initialize params: %w", err)
+	}
+
+	var caps *ClientCapabilities
+	for _, entry := range []struct {
+		raw      json.RawMessage
+		defaults []string
+	}{
+		{params.Capabilities.Experimental[CapabilityKey], defaultMIMETypes},
+		{params.Meta[CapabilityKey], defaultMIMETypes},
+		{params.Capabilities.Extensions[MCPAppsExtension], []string{MIMETypeMCPApp}},
+	} {
+		if len(entry.raw) == 0 || string(entry.raw) == "null" {
+			continue
+		}
+		var c ClientCapabilities
+		if err := json.Unmarshal(entry.raw, &c); err != nil {
+			return nil, fmt.Errorf("parsing UI capabilities: %w", err)
+		}
+		if len(c.MIMETypes) == 0 {
+			c.MIMETypes = slices.Clone(entry.defaults)
+		}
+		if caps == nil {
+			caps = &ClientCapabilities{}
+		}
+		caps.merge(&c)
+	}
+	return caps, nil
+}
+
+func (c *ClientCapabilities) merge(other *ClientCapabilities) {
+	for _, m := range other.MIMETypes {
+		if !slices.Contains(c.MIMETypes, m) {
+			c.MIMETypes = append(c.MIMETypes, m)
+		}
+	}
+	for _, f := range other.Frameworks {
+		if !slices.Contains(c.Frameworks, f) {
+			c.Frameworks = append(c.Frameworks, f)
+		}
+	}
+}
+
+// Supports reports whether the client can render content. A nil
+// ClientCapabilities supports nothing.
+func (c *ClientCapabilities) Supports(content UIContent) bool {
+	if content == nil || c == nil {
+		return false
+	}
+	mt, err := ParseMediaType(content.mimeType())
+	if err != nil {
+		return false
+	}
+	accepted := c.MIMETypes
+	if len(accepted) == 0 {
+		accepted = defaultMIMETypes
+	}
+	if !slices.ContainsFunc(accepted, func(accept string) bool { return mediaTypeMatches(accept, mt) }) {
+		return false
+	}
+	if rd, ok := content.(*RemoteDOMContent); ok && len(c.Frameworks) > 0 && rd.Framework != "" {
+		return slices.Contains(c.Frameworks, rd.Framework)
+	}
+	return true
+}
+
+// defaultMIMETypes are supported by clients that advertise UI support
+// without listing MIME types.
+var defaultMIMETypes = []string{MIMETypeHTML, MIMETypeURLList}
+
+// mediaTypeMatches reports whether the capability entry accept matches mt.
+func mediaTypeMatches(accept string, mt MediaType) bool {
+	want, err := ParseMediaType(accept)
+	if err != nil {
+		return false
+	}
+	major, _, _ := strings.Cut(mt.Type, "/")
+	switch want.Type {
+	case "*/*", major + "/*", mt.Type:
+	case MIMETypeRemoteDOM:
+		if !strings.HasPrefix(mt.Type, MIMETypeRemoteDOM+"+") {
+			return false
+		}
+	default:
+		return false
+	}
+	for k, v := range want.Params {
+		if !strings.EqualFold(mt.Params[k], v) {
+			return false
+		}
+	}
+	// A profile changes how content is rendered, so it must be accepted
+	// explicitly.
+	return strings.EqualFold(mt.Param(MIMEParamProfile), want.Param(MIMEParamProfile))
+}
+
+// Negotiate picks the content to send to a client from alternatives
+// renderings of the same UI, listed in order of preference. It returns the
+// first alternative the client supports. If caps is nil, the client did not
+// advertise UI support and no alternative is supported. If none is
+// supported, it returns false and the caller should send text instead,
+// such as the [TextFallback] of the preferred alternative.
+//
+// Example:
+//
+//	content, ok := mcpui.Negotiate(caps,
+//		&mcpui.RemoteDOMContent{Script: script, Framework: mcpui.FrameworkReact},
+//		&mcpui.HTMLContent{HTML: html},
+//	)
+func Negotiate(caps *ClientCapabilities, alternatives ...UIContent) (UIContent, bool) {
+	for _, content := range alternatives {
+		if caps.Supports(content) {
+			return content, true
+		}
+	}
+	return nil, false
+}
+
+// Negotiated adds the alternative chosen by [Negotiate] as a UI resource,
+// or, if the client supports none, the [TextFallback] of the first
+// alternative as text.
+func (b *ToolResultBuilder) Negotiated(caps *ClientCapabilities, uri string, alternatives ...UIContent) *ToolResultBuilder {
+	if content, ok := Negotiate(caps, alternatives...); ok {
+		return b.UI(uri, content)
+	}
+	if len(alternatives) == 0 {
+		b.fail(fmt.Errorf("UI resource %q: no alternatives", uri))
+		return b
+	}
+	return b.Text(TextFallback(alternatives[0]))
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientCapabilities(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		want    *ClientCapabilities
+		wantErr bool
+	}{
+		{
+			name:   "experimental",
+			params: `{"protocolVersion":"2025-06-18","capabilities":{"experimental":{"mcp-ui":{"mimeTypes":["text/html"],"frameworks":["react"]}}}}`,
+			want:   &ClientCapabilities{MIMETypes: []string{"text/html"}, Frameworks: []Framework{FrameworkReact}},
+		},
+		{
+			name:   "meta",
+			params: `{"capabilities":{},"_meta":{"mcp-ui":{"mimeTypes":["text/uri-list"]}}}`,
+			want:   &ClientCapabilities{MIMETypes: []string{"text/uri-list"}},
+		},
+		{
+			name:   "merged",
+			params: `{"capabilities":{"experimental":{"mcp-ui":{"mimeTypes":["text/html"]}}},"_meta":{"mcp-ui":{"mimeTypes":["text/html","text/uri-list"]}}}`,
+			want:   &ClientCapabilities{MIMETypes: []string{"text/html", "text/uri-list"}},
+		},
+		{
+			name:   "presence only means the default set",
+			params: `{"capabilities":{"experimental":{"mcp-ui":{}}}}`,
+			want:   &ClientCapabilities{MIMETypes: []string{"text/html", "text/uri-list"}},
+		},
+		{
+			name:   "presence only merged with a list",
+			params: `{"capabilities":{"experimental":{"mcp-ui":{"frameworks":["react"]}}},"_meta":{"mcp-ui":{"mimeTypes":["application/vnd.mcp-ui.remote-dom"]}}}`,
+			want:   &ClientCapabilities{MIMETypes: []string{"text/html", "text/uri-list", MIMETypeRemoteDOM}, Frameworks: []Framework{FrameworkReact}},
+		},
+		{
+			name:   "presence only MCP Apps",
+			params: `{"capabilities":{"extensions":{"io.modelcontextprotocol/ui":{}}}}`,
+			want:   &ClientCapabilities{MIMETypes: []string{MIMETypeMCPApp}},
+		},
+		{
+			name:   "not advertised",
+			params: `{"capabilities":{"experimental":{"other":{}}}}`,
+			want:   nil,
+		},
+		{
+			name:    "malformed params",
+			params:  `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed capability",
+			params:  `{"capabilities":{"experimental":{"mcp-ui":{"mimeTypes":"text/html"}}}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caps, err := ParseClientCapabilities(json.RawMessage(tt.params))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, caps)
+		})
+	}
+}
+
+func TestClientCapabilities_Supports(t *testing.T) {
+	html := &HTMLContent{HTML: "<p>x</p>", Charset: "utf-8"}
+	url := &URLContent{URL: "https://example.com"}
+	react := &RemoteDOMContent{Script: "x", Framework: FrameworkReact}
+	wc := &RemoteDOMContent{Script: "x", Framework: FrameworkWebComponents}
+	png := &BlobContent{Data: []byte{1}, ContentMIMEType: "image/png"}
+
+	tests := []struct {
+		name string
+		caps *ClientCapabilities
+		yes  []UIContent
+		no   []UIContent
+	}{
+		{"nil is text only", nil, nil, []UIContent{html, url, react, png, nil}},
+		{"presence only", &ClientCapabilities{}, []UIContent{html, url}, []UIContent{react, png, &MCPAppContent{HTML: "x"}}},
+		{"html only", &ClientCapabilities{MIMETypes: []string{"text/html"}}, []UIContent{html}, []UIContent{url, react}},
+		{"remote dom base type", &ClientCapabilities{MIMETypes: []string{MIMETypeRemoteDOM}}, []UIContent{react, wc}, []UIContent{html}},
+		{"framework filter", &ClientCapabilities{MIMETypes: []string{MIMETypeRemoteDOM}, Frameworks: []Framework{FrameworkReact}}, []UIContent{react}, []UIContent{wc}},
+		{"wildcards", &ClientCapabilities{MIMETypes: []string{"text/*", "image/*"}}, []UIContent{html, url, png}, []UIContent{react}},
+		{"any", &ClientCapabilities{MIMETypes: []string{"*/*"}}, []UIContent{html, url, react, png}, nil},
+		{"parameters must match", &ClientCapabilities{MIMETypes: []string{"text/html; charset=UTF-8"}}, []UIContent{html}, []UIContent{&HTMLContent{HTML: "x"}}},
+		{"invalid entry ignored", &ClientCapabilities{MIMETypes: []string{"not a type", "text/uri-list"}}, []UIContent{url}, []UIContent{html}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range tt.yes {
+				assert.True(t, tt.caps.Supports(c), "%T", c)
+			}
+			for _, c := range tt.no {
+				assert.False(t, tt.caps.Supports(c), "%T", c)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	react := &RemoteDOMContent{Script: "x", Framework: FrameworkReact}
+	html := &HTMLContent{HTML: "<h1>Hi</h1>"}
+
+	content, ok := Negotiate(&ClientCapabilities{MIMETypes: []string{"text/html"}}, react, html)
+	assert.True(t, ok)
+	assert.Same(t, html, content)
+
+	content, ok = Negotiate(&ClientCapabilities{MIMETypes: []string{"text/html", MIMETypeRemoteDOM}}, react, html)
+	assert.True(t, ok)
+	assert.Same(t, react, content, "the first supported alternative wins")
+
+	content, ok = Negotiate(nil, react, html)
+	assert.False(t, ok)
+	assert.Nil(t, content)
+
+	content, ok = Negotiate(&ClientCapabilities{}, react, html)
+	assert.True(t, ok)
+	assert.Same(t, html, content, "presence only accepts the default set")
+
+	_, ok = Negotiate(nil)
+	assert.False(t, ok)
+}
+
+func TestToolResultBuilder_Negotiated(t *testing.T) {
+	html := &HTMLContent{HTML: "<h1>Hi</h1>"}
+
+	result, err := NewToolResult().Negotiated(&ClientCapabilities{MIMETypes: []string{"text/html"}}, "ui://hi", html).Build()
+	require.NoError(t, err)
+	require.Len(t, result.UIResources(), 1)
+	assert.Equal(t, "ui://hi", result.UIResources()[0].URI)
+
+	result, err = NewToolResult().Negotiated(nil, "ui://hi", html).Build()
+	require.NoError(t, err)
+	assert.Equal(t, []*ToolContent{{Type: ToolContentText, Text: "# Hi"}}, result.Content)
+
+	_, err = NewToolResult().Negotiated(nil, "ui://hi").Build()
+	assert.Error(t, err)
+}
@@ -0,0 +1,346 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OpenAI Apps SDK identifiers.
+const (
+	// MIMETypeSkybridge is the MIME type of OpenAI Apps SDK widgets.
+	MIMETypeSkybridge = "text/html+skybridge"
+	// DefaultOpenAIActionTool is the default name of the tool that
+	// receives the actions of adapted widgets.
+	DefaultOpenAIActionTool = "mcpui_action"
+)
+
+// OpenAI Apps SDK tool _meta keys.
+const (
+	// MetaKeyOutputTemplate links a tool to the ui:// URI of the widget
+	// that renders its results.
+	MetaKeyOutputTemplate = "openai/outputTemplate"
+	// MetaKeyWidgetAccessible allows widgets to call the tool.
+	MetaKeyWidgetAccessible = "openai/widgetAccessible"
+	// MetaKeyOpenAIVisibility hides a tool from the model when set to
+	// "private".
+	MetaKeyOpenAIVisibility = "openai/visibility"
+)
+
+// OpenAI Apps SDK resource _meta keys, read and written by
+// [SkybridgeContent].
+const (
+	metaKeyWidgetDescription   = "openai/widgetDescription"
+	metaKeyWidgetPrefersBorder = "openai/widgetPrefersBorder"
+	metaKeyWidgetCSP           = "openai/widgetCSP"
+	metaKeyWidgetDomain        = "openai/widgetDomain"
+)
+
+// SkybridgeContent is HTML for the OpenAI Apps SDK, served with the
+// "text/html+skybridge" MIME type. The page talks to ChatGPT through the
+// window.openai API rather than postMessage; use [OpenAIAdapter] to convert
+// [HTMLContent] written for MCP-UI hosts.
+type SkybridgeContent struct {
+	// HTML is the document to render.
+	HTML string
+	// Widget holds the resource metadata, if any.
+	Widget *OpenAIWidgetMeta
+	// Annotations contains optional metadata.
+	Annotations *Annotations
+}
+
+// OpenAIWidgetMeta is the _meta of an OpenAI Apps SDK widget resource.
+type OpenAIWidgetMeta struct {
+	// Description describes the widget to the model.
+	Description string
+	// PrefersBorder asks ChatGPT to draw a border around the widget.
+	PrefersBorder *bool
+	// CSP lists the external origins the widget may use.
+	CSP *OpenAIWidgetCSP
+	// Domain requests a dedicated origin for the widget's sandbox.
+	Domain string
+}
+
+// OpenAIWidgetCSP lists the origins ChatGPT adds to a widget's Content
+// Security Policy.
+type OpenAIWidgetCSP struct {
+	// ConnectDomains are origins for fetch, XHR and WebSocket connections.
+	ConnectDomains []string `json:"connect_domains,omitempty"`
+	// ResourceDomains are origins for scripts, styles, images and fonts.
+	ResourceDomains []string `json:"resource_domains,omitempty"`
+}
+
+// MarshalJSON serializes SkybridgeContent to the wire format.
+func (c *SkybridgeContent) MarshalJSON() ([]byte, error) {
+	wire := &wireUIContent{
+		MIMEType:    c.mimeType(),
+		Text:        c.HTML,
+		Annotations: c.Annotations,
+	}
+	if c.Widget != nil {
+		wire.Meta = c.Widget.meta()
+	}
+	return json.Marshal(wire)
+}
+
+func (c *SkybridgeContent) mimeType() string { return MIMETypeSkybridge }
+
+func (c *SkybridgeContent) fromWire(wire *wireUIContent) error {
+	text, _, err := wire.text()
+	if err != nil {
+		return err
+	}
+	c.HTML = text
+	c.Annotations = wire.Annotations
+	c.Widget, err = parseOpenAIWidgetMeta(wire.Meta)
+	return err
+}
+
+// meta returns the resource _meta entries of m.
+func (m *OpenAIWidgetMeta) meta() map[string]any {
+	meta := make(map[string]any)
+	if m.Description != "" {
+		meta[metaKeyWidgetDescription] = m.Description
+	}
+	if m.PrefersBorder != nil {
+		meta[metaKeyWidgetPrefersBorder] = *m.PrefersBorder
+	}
+	if m.CSP != nil {
+		meta[metaKeyWidgetCSP] = m.CSP
+	}
+	if m.Domain != "" {
+		meta[metaKeyWidgetDomain] = m.Domain
+	}
+	if len(meta) == 0 {
+		return nil
+	}
+	return meta
+}
+
+// parseOpenAIWidgetMeta reads the widget entries of a resource _meta. It
+// returns nil if there are none.
+func parseOpenAIWidgetMeta(meta map[string]any) (*OpenAIWidgetMeta, error) {
+	var m OpenAIWidgetMeta
+	found := false
+	if v, ok := meta[metaKeyWidgetDescription]; ok {
+		if err := remarshal(v, &m.Description); err != nil {
+			return nil, fmt.Errorf("invalid _meta.%s: %w", metaKeyWidgetDescription, err)
+		}
+		found = true
+	}
+	if v, ok := meta[metaKeyWidgetPrefersBorder]; ok {
+		if err := remarshal(v, &m.PrefersBorder); err != nil {
+			return nil, fmt.Errorf("invalid _meta.%s: %w", metaKeyWidgetPrefersBorder, err)
+		}
+		found = true
+	}
+	if v, ok := meta[metaKeyWidgetCSP]; ok {
+		if err := remarshal(v, &m.CSP); err != nil {
+			return nil, fmt.Errorf("invalid _meta.%s: %w", metaKeyWidgetCSP, err)
+		}
+		found = true
+	}
+	if v, ok := meta[metaKeyWidgetDomain]; ok {
+		if err := remarshal(v, &m.Domain); err != nil {
+			return nil, fmt.Errorf("invalid _meta.%s: %w", metaKeyWidgetDomain, err)
+		}
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return &m, nil
+}
+
+// SetOpenAIToolMeta links a tool to the widget that renders its results,
+// storing outputTemplate in the tool's _meta, creating the map if needed,
+// and returns it. The tool is also made callable from widgets.
+//
+// Example:
+//
+//	tool.Meta = mcpui.SetOpenAIToolMeta(tool.Meta, "ui://weather/forecast")
+func SetOpenAIToolMeta(meta map[string]any, outputTemplate string) map[string]any {
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[MetaKeyOutputTemplate] = outputTemplate
+	meta[MetaKeyWidgetAccessible] = true
+	return meta
+}
+
+// OpenAIAdapter serves MCP-UI HTML resources to ChatGPT through the OpenAI
+// Apps SDK. [OpenAIAdapter.Adapt] rewrites [HTMLContent] into
+// [SkybridgeContent] with a shim that turns the page's MCP-UI actions into
+// calls of a single action tool. Register that tool with the MCP server and
+// answer it with [OpenAIAdapter.CallActionTool], which dispatches the
+// actions to the same [Router] that serves MCP-UI hosts.
+//
+// The shim handles tool, intent, prompt and notify actions by calling the
+// action tool, and link actions by opening the URL through ChatGPT. The
+// page receives the usual ui-message-received and ui-message-response
+// messages, and the tool output as render data.
+//
+// Example:
+//
+//	adapter := mcpui.NewOpenAIAdapter(router)
+//	widget, err := adapter.Adapt("ui://dashboard/main", &mcpui.HTMLContent{HTML: page})
+//	...
+//	// Tool "mcpui_action", with adapter.ActionToolMeta() as its _meta:
+//	return adapter.CallActionTool(ctx, session, arguments), nil
+type OpenAIAdapter struct {
+	// ActionTool is the name of the action tool. Empty means
+	// DefaultOpenAIActionTool.
+	ActionTool string
+	dispatcher ActionDispatcher
+}
+
+// NewOpenAIAdapter creates an adapter whose action tool dispatches to d.
+func NewOpenAIAdapter(d ActionDispatcher) *OpenAIAdapter {
+	return &OpenAIAdapter{dispatcher: d}
+}
+
+// OpenAIActionToolSchema is the input schema of the action tool.
+var OpenAIActionToolSchema = json.RawMessage(`{"type":"object","properties":{"action":{"type":"object","properties":{"type":{"type":"string"},"messageId":{"type":"string"},"payload":{"type":"object"}},"required":["type"]},"resourceUri":{"type":"string"}},"required":["action"]}`)
+
+func (a *OpenAIAdapter) actionTool() string {
+	if a.ActionTool == "" {
+		return DefaultOpenAIActionTool
+	}
+	return a.ActionTool
+}
+
+// ActionToolMeta returns the _meta of the action tool: callable from
+// widgets and hidden from the model.
+func (a *OpenAIAdapter) ActionToolMeta() map[string]any {
+	return map[string]any{
+		MetaKeyWidgetAccessible: true,
+		MetaKeyOpenAIVisibility: "private",
+	}
+}
+
+// Adapt converts HTML content served at uri into a widget. The shim is
+// injected at the start of the document head so it runs before the page's
+// scripts. Charset, profile and encoding are dropped; the widget is always
+// sent as text.
+func (a *OpenAIAdapter) Adapt(uri string, content *HTMLContent) (*SkybridgeContent, error) {
+	if !strings.HasPrefix(uri, URIScheme) {
+		return nil, fmt.Errorf("resource URI must start with %s: %q", URIScheme, uri)
+	}
+	if content == nil {
+		return nil, fmt.Errorf("content is required")
+	}
+	return &SkybridgeContent{
+		HTML:        injectHead(tokenizeHTML(content.HTML), openAIShim(a.actionTool(), uri)),
+		Annotations: content.Annotations,
+	}, nil
+}
+
+// AdaptResource converts resource contents holding [HTMLContent], such as
+// those returned by [ResourceRegistry.Read], into a widget resource with
+// the same URI.
+func (a *OpenAIAdapter) AdaptResource(rc *UIResourceContents) (*UIResourceContents, error) {
+	if rc == nil {
+		return nil, fmt.Errorf("resource contents are required")
+	}
+	content, err := rc.ToUIContent()
+	if err != nil {
+		return nil, err
+	}
+	html, ok := content.(*HTMLContent)
+	if !ok {
+		return nil, fmt.Errorf("resource %q: cannot adapt %s content", rc.URI, rc.MIMEType)
+	}
+	widget, err := a.Adapt(rc.URI, html)
+	if err != nil {
+		return nil, err
+	}
+	return NewUIResourceContents(rc.URI, widget)
+}
+
+// CallActionTool handles a call of the action tool with the given
+// arguments, dispatching the action with session as
+// [UIActionRequest].Session. The [UIResponse] is returned as structured
+// content, which the shim delivers to the page. Failures, including
+// invalid arguments, are reported as tool errors carrying an error
+// response.
+func (a *OpenAIAdapter) CallActionTool(ctx context.Context, session any, arguments json.RawMessage) *ToolResult {
+	var args struct {
+		Action      *UIAction `json:"action"`
+		ResourceURI string    `json:"resourceUri"`
+	}
+	var resp *UIResponse
+	switch {
+	case json.Unmarshal(arguments, &args) != nil || args.Action == nil:
+		resp = NewErrorResponseWithCode("", ErrorCodeInvalidRequest, "arguments must contain an action")
+	case args.ResourceURI != "" && !strings.HasPrefix(args.ResourceURI, URIScheme):
+		resp = NewErrorResponseWithCode(args.Action.MessageID, ErrorCodeInvalidRequest, "resource URI must start with "+URIScheme)
+	default:
+		_, resp = dispatchAction(ctx, a.dispatcher, args.Action, args.ResourceURI, session)
+	}
+
+	result := &ToolResult{
+		Content:           []*ToolContent{},
+		StructuredContent: resp,
+		IsError:           resp.Payload != nil && resp.Payload.Error != nil,
+	}
+	if data, err := json.Marshal(resp); err == nil {
+		result.Content = append(result.Content, &ToolContent{Type: ToolContentText, Text: string(data)})
+	}
+	return result
+}
+
+// openAIShim returns the script that bridges an MCP-UI page to the
+// window.openai API. It replaces window.parent so the page's postMessage
+// calls reach the shim, and answers with message events on window.
+func openAIShim(tool, uri string) string {
+	actions, _ := json.Marshal([]string{ActionTypeTool, ActionTypeIntent, ActionTypePrompt, ActionTypeNotify, ActionTypeLink})
+
+	var b strings.Builder
+	b.WriteString("<script>(function () {\n")
+	b.WriteString("  if (window.__mcpuiOpenAIShim) { return; }\n")
+	b.WriteString("  window.__mcpuiOpenAIShim = true;\n")
+	b.WriteString("  var tool = " + jsString(tool) + ", uri = " + jsString(uri) + ";\n")
+	b.WriteString("  var actions = " + string(actions) + ";\n")
+	b.WriteString("  var host = window.parent;\n")
+	b.WriteString("  function deliver(data) { window.dispatchEvent(new MessageEvent(\"message\", { data: data })); }\n")
+	b.WriteString("  function respond(id, payload) {\n")
+	b.WriteString("    if (id) { deliver({ type: " + jsString(ResponseTypeResponse) + ", messageId: id, payload: payload }); }\n")
+	b.WriteString("  }\n")
+	b.WriteString("  function handle(msg) {\n")
+	b.WriteString("    var api = window.openai;\n")
+	b.WriteString("    if (!api || !msg || actions.indexOf(msg.type) === -1) { return false; }\n")
+	b.WriteString("    var id = msg.messageId;\n")
+	b.WriteString("    if (id) { deliver({ type: " + jsString(ResponseTypeReceived) + ", messageId: id }); }\n")
+	b.WriteString("    if (msg.type === " + jsString(ActionTypeLink) + " && api.openExternal) {\n")
+	b.WriteString("      api.openExternal({ href: msg.payload && msg.payload.url });\n")
+	b.WriteString("      respond(id, {});\n")
+	b.WriteString("      return true;\n")
+	b.WriteString("    }\n")
+	b.WriteString("    api.callTool(tool, { action: msg, resourceUri: uri }).then(function (result) {\n")
+	b.WriteString("      var resp = result && result.structuredContent;\n")
+	b.WriteString("      if (resp && resp.type === " + jsString(ResponseTypeResponse) + ") { if (id) { deliver(resp); } }\n")
+	b.WriteString("      else { respond(id, { response: result }); }\n")
+	b.WriteString("    }, function (err) {\n")
+	b.WriteString("      respond(id, { error: { message: String((err && err.message) || err) } });\n")
+	b.WriteString("    });\n")
+	b.WriteString("    return true;\n")
+	b.WriteString("  }\n")
+	b.WriteString("  Object.defineProperty(window, \"parent\", { configurable: true, value: {\n")
+	b.WriteString("    postMessage: function (msg, origin, transfer) { if (!handle(msg)) { host.postMessage(msg, origin || \"*\", transfer); } }\n")
+	b.WriteString("  } });\n")
+	b.WriteString("  function renderData() {\n")
+	b.WriteString("    var api = window.openai;\n")
+	b.WriteString("    if (api && api.toolOutput != null) { deliver({ type: " + jsString(EventRenderData) + ", payload: { renderData: api.toolOutput } }); }\n")
+	b.WriteString("  }\n")
+	b.WriteString("  window.addEventListener(\"openai:set_globals\", function (e) {\n")
+	b.WriteString("    if (e.detail && e.detail.globals && \"toolOutput\" in e.detail.globals) { renderData(); }\n")
+	b.WriteString("  });\n")
+	b.WriteString("  if (document.readyState === \"loading\") { document.addEventListener(\"DOMContentLoaded\", renderData); } else { renderData(); }\n")
+	b.WriteString("})();</script>")
+	return b.String()
+}
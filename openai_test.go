@@ -0,0 +1,185 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkybridgeContent_Wire(t *testing.T) {
+	border := false
+	content := &SkybridgeContent{
+		HTML: "<p>widget</p>",
+		Widget: &OpenAIWidgetMeta{
+			Description:   "Shows the forecast",
+			PrefersBorder: &border,
+			CSP:           &OpenAIWidgetCSP{ConnectDomains: []string{"https://api.example.com"}},
+		},
+	}
+	rc, err := NewUIResourceContents("ui://weather/forecast", content)
+	require.NoError(t, err)
+	data, err := json.Marshal(rc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"uri": "ui://weather/forecast",
+		"mimeType": "text/html+skybridge",
+		"text": "<p>widget</p>",
+		"_meta": {
+			"openai/widgetDescription": "Shows the forecast",
+			"openai/widgetPrefersBorder": false,
+			"openai/widgetCSP": {"connect_domains": ["https://api.example.com"]}
+		}
+	}`, string(data))
+
+	var decoded UIResourceContents
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	back, err := decoded.ToUIContent()
+	require.NoError(t, err)
+	assert.Equal(t, content, back)
+
+	assert.Equal(t, "widget", TextFallback(content))
+}
+
+func TestSkybridgeContent_NoMeta(t *testing.T) {
+	data, err := json.Marshal(&SkybridgeContent{HTML: "<p>x</p>", Widget: &OpenAIWidgetMeta{}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"mimeType": "text/html+skybridge", "text": "<p>x</p>"}`, string(data))
+
+	back, err := ContentFromWire(&wireUIContent{MIMEType: MIMETypeSkybridge, Text: "<p>x</p>"})
+	require.NoError(t, err)
+	assert.Equal(t, &SkybridgeContent{HTML: "<p>x</p>"}, back)
+
+	_, err = ContentFromWire(&wireUIContent{
+		MIMEType: MIMETypeSkybridge,
+		Meta:     map[string]any{"openai/widgetCSP": "none"},
+	})
+	assert.ErrorContains(t, err, "openai/widgetCSP")
+}
+
+func TestSetOpenAIToolMeta(t *testing.T) {
+	meta := SetOpenAIToolMeta(map[string]any{"other": 1}, "ui://weather/forecast")
+	assert.Equal(t, map[string]any{
+		"other":                   1,
+		"openai/outputTemplate":   "ui://weather/forecast",
+		"openai/widgetAccessible": true,
+	}, meta)
+	assert.Equal(t, "ui://x", SetOpenAIToolMeta(nil, "ui://x")[MetaKeyOutputTemplate])
+}
+
+func TestOpenAIAdapter_Adapt(t *testing.T) {
+	adapter := NewOpenAIAdapter(NewRouter())
+	content := &HTMLContent{
+		HTML:        "<!DOCTYPE html><html><head><title>T</title></head><body><p>Hi</p></body></html>",
+		Charset:     "utf-8",
+		Annotations: &Annotations{Audience: []string{"user"}},
+	}
+	widget, err := adapter.Adapt("ui://dashboard/main", content)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(widget.HTML, "<!DOCTYPE html><html><head><script>"), "shim runs before the page's scripts")
+	assert.True(t, strings.HasSuffix(widget.HTML, "</script><title>T</title></head><body><p>Hi</p></body></html>"))
+	assert.Contains(t, widget.HTML, `var tool = "mcpui_action", uri = "ui://dashboard/main";`)
+	assert.Contains(t, widget.HTML, `["tool","intent","prompt","notify","link"]`)
+	assert.Contains(t, widget.HTML, "api.callTool(tool, { action: msg, resourceUri: uri })")
+	assert.Equal(t, content.Annotations, widget.Annotations)
+	assert.Equal(t, MIMETypeSkybridge, widget.mimeType())
+
+	adapter.ActionTool = "ui_action"
+	widget, err = adapter.Adapt("ui://x</script>", &HTMLContent{HTML: "<p>fragment</p>"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(widget.HTML, "<script>"))
+	assert.Contains(t, widget.HTML, `var tool = "ui_action", uri = "ui://x\u003c/script\u003e";`, "values cannot end the script")
+	assert.Equal(t, 1, strings.Count(widget.HTML, "</script>"))
+
+	_, err = adapter.Adapt("https://example.com", content)
+	assert.Error(t, err)
+	_, err = adapter.Adapt("ui://x", nil)
+	assert.Error(t, err)
+}
+
+func TestOpenAIAdapter_AdaptResource(t *testing.T) {
+	adapter := NewOpenAIAdapter(NewRouter())
+
+	rc, err := NewUIResourceContents("ui://page", &HTMLContent{HTML: "<p>Hi</p>", Encoding: EncodingBlob})
+	require.NoError(t, err)
+	adapted, err := adapter.AdaptResource(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "ui://page", adapted.URI)
+	assert.Equal(t, MIMETypeSkybridge, adapted.MIMEType)
+	assert.Nil(t, adapted.Blob, "widgets are sent as text")
+	assert.True(t, strings.HasSuffix(adapted.Text, "</script><p>Hi</p>"))
+
+	rc, err = NewUIResourceContents("ui://link", &URLContent{URL: "https://example.com"})
+	require.NoError(t, err)
+	_, err = adapter.AdaptResource(rc)
+	assert.ErrorContains(t, err, "cannot adapt text/uri-list content")
+
+	_, err = adapter.AdaptResource(nil)
+	assert.Error(t, err)
+}
+
+func TestOpenAIAdapter_CallActionTool(t *testing.T) {
+	adapter := NewOpenAIAdapter(testHTTPRouter())
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		result := adapter.CallActionTool(ctx, "s1", json.RawMessage(`{
+			"action": {"type": "tool", "messageId": "m1", "payload": {"toolName": "refresh", "params": {}}},
+			"resourceUri": "ui://dashboard/main"
+		}`))
+		assert.False(t, result.IsError)
+		resp := result.StructuredContent.(*UIResponse)
+		assert.Equal(t, ResponseTypeResponse, resp.Type)
+		assert.Equal(t, "m1", resp.MessageID)
+		assert.Equal(t, map[string]any{"tool": "refresh"}, resp.Payload.Response)
+		require.Len(t, result.Content, 1)
+		assert.JSONEq(t, `{"type": "ui-message-response", "messageId": "m1", "payload": {"response": {"tool": "refresh"}}}`, result.Content[0].Text)
+	})
+
+	t.Run("session and resource", func(t *testing.T) {
+		result := adapter.CallActionTool(ctx, "s1", json.RawMessage(`{
+			"action": {"type": "notify", "payload": {"message": "hi"}},
+			"resourceUri": "ui://dashboard/main"
+		}`))
+		resp := result.StructuredContent.(*UIResponse)
+		assert.Equal(t, map[string]any{"session": "s1", "resource": "ui://dashboard/main"}, resp.Payload.Response)
+	})
+
+	tests := []struct {
+		name      string
+		arguments string
+		code      string
+	}{
+		{"invalid JSON", `{`, ErrorCodeInvalidRequest},
+		{"missing action", `{"resourceUri": "ui://x"}`, ErrorCodeInvalidRequest},
+		{"missing type", `{"action": {"payload": {}}}`, ErrorCodeInvalidRequest},
+		{"bad URI", `{"action": {"type": "notify", "payload": {}}, "resourceUri": "https://x"}`, ErrorCodeInvalidRequest},
+		{"no handler", `{"action": {"type": "link", "payload": {"url": "https://example.com"}}}`, ErrorCodeNoHandler},
+		{"malformed payload", `{"action": {"type": "tool", "payload": {"toolName": 5}}}`, ErrorCodeInvalidRequest},
+		{"handler error", `{"action": {"type": "prompt", "payload": {"prompt": "x"}}}`, ErrorCodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := adapter.CallActionTool(ctx, nil, json.RawMessage(tt.arguments))
+			assert.True(t, result.IsError)
+			resp := result.StructuredContent.(*UIResponse)
+			require.NotNil(t, resp.Payload.Error)
+			assert.Equal(t, tt.code, resp.Payload.Error.Code)
+		})
+	}
+}
+
+func TestNegotiate_Skybridge(t *testing.T) {
+	widget := &SkybridgeContent{HTML: "<p>x</p>"}
+	assert.False(t, (&ClientCapabilities{MIMETypes: []string{"text/html"}}).Supports(widget), "plain HTML hosts cannot run widgets")
+	assert.True(t, (&ClientCapabilities{MIMETypes: []string{"text/html+skybridge"}}).Supports(widget))
+	assert.True(t, (&ClientCapabilities{MIMETypes: []string{"text/*"}}).Supports(widget))
+}
@@ -0,0 +1,393 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultPageSize is the default number of resources per List page.
+const DefaultPageSize = 50
+
+// ErrInvalidCursor is returned by [ResourceRegistry.List] and
+// [ResourceRegistry.ListTemplates] for cursors they did not issue.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ResourceReadFunc produces the content of a dynamic resource on each read.
+type ResourceReadFunc func(ctx context.Context, uri string) (UIContent, error)
+
+// TemplateReadFunc produces the content of a resource matched by a
+// [UIResourceTemplate]. vars holds the variables extracted from uri, see
+// [URITemplate.Match].
+type TemplateReadFunc func(ctx context.Context, uri string, vars map[string]string) (UIContent, error)
+
+// ResourceRegistry holds UI resources and serves MCP resources/list and
+// resources/read requests. It is safe for concurrent use.
+//
+// Static resources have fixed content; dynamic resources call a
+// [ResourceReadFunc] on every read. Resource templates serve whole families
+// of URIs: reads of URIs that are not registered are matched against the
+// templates in registration order. Resources and templates are listed in
+// URI order and paginated with opaque cursors that are signed, so clients
+// cannot forge or modify them. Clients may subscribe to the resources the
+// registry serves, see [ResourceRegistry.Subscribe].
+//
+// Example:
+//
+//	registry := mcpui.NewResourceRegistry()
+//	registry.Register(&mcpui.UIResource{URI: "ui://help", Name: "help"},
+//		&mcpui.HTMLContent{HTML: helpHTML})
+//	registry.RegisterFunc(&mcpui.UIResource{URI: "ui://status", Name: "status"},
+//		func(ctx context.Context, uri string) (mcpui.UIContent, error) {
+//			return &mcpui.HTMLContent{HTML: renderStatus()}, nil
+//		})
+//
+//	registry.RegisterTemplate(&mcpui.UIResourceTemplate{URITemplate: "ui://orders/{id}", Name: "order"},
+//		func(ctx context.Context, uri string, vars map[string]string) (mcpui.UIContent, error) {
+//			return &mcpui.HTMLContent{HTML: renderOrder(vars["id"])}, nil
+//		})
+//
+//	page, err := registry.List(ctx, "")
+//	result, err := registry.Read(ctx, "ui://status")
+type ResourceRegistry struct {
+	mu        sync.RWMutex
+	resources map[string]*registryEntry
+	uris      []string         // sorted
+	templates []*templateEntry // in registration order
+	pageSize  int
+	key       []byte
+	subs      *SubscriptionManager
+}
+
+type registryEntry struct {
+	resource *UIResource
+	read     ResourceReadFunc
+}
+
+type templateEntry struct {
+	template *UIResourceTemplate
+	parsed   *URITemplate
+	read     TemplateReadFunc
+}
+
+// NewResourceRegistry creates an empty registry using [DefaultPageSize] and
+// a random cursor signing key.
+func NewResourceRegistry() *ResourceRegistry {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key) // crypto/rand.Read never returns an error
+	return &ResourceRegistry{
+		resources: make(map[string]*registryEntry),
+		pageSize:  DefaultPageSize,
+		key:       key,
+		subs:      NewSubscriptionManager(nil),
+	}
+}
+
+// SetPageSize sets the maximum number of resources per List page.
+// Values below 1 restore [DefaultPageSize].
+func (r *ResourceRegistry) SetPageSize(n int) {
+	if n < 1 {
+		n = DefaultPageSize
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pageSize = n
+}
+
+// SetCursorKey sets the key used to sign cursors. Servers running several
+// replicas behind a load balancer must share a key so that cursors issued by
+// one replica are accepted by the others.
+func (r *ResourceRegistry) SetCursorKey(key []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.key = slices.Clone(key)
+}
+
+// Register adds a resource with fixed content, replacing any resource with
+// the same URI. If the resource has no MIMEType, it is taken from content.
+func (r *ResourceRegistry) Register(resource *UIResource, content UIContent) error {
+	if resource == nil {
+		return errors.New("resource is required")
+	}
+	if content == nil {
+		return errors.New("content is required")
+	}
+	res := *resource
+	if res.MIMEType == "" {
+		res.MIMEType = content.mimeType()
+	}
+	return r.add(&res, func(context.Context, string) (UIContent, error) {
+		return content, nil
+	})
+}
+
+// RegisterFunc adds a dynamic resource whose content is produced by read,
+// replacing any resource with the same URI.
+func (r *ResourceRegistry) RegisterFunc(resource *UIResource, read ResourceReadFunc) error {
+	if resource == nil {
+		return errors.New("resource is required")
+	}
+	if read == nil {
+		return errors.New("read function is required")
+	}
+	res := *resource
+	return r.add(&res, read)
+}
+
+func (r *ResourceRegistry) add(resource *UIResource, read ResourceReadFunc) error {
+	if err := resource.Validate(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.resources[resource.URI]; !exists {
+		i, _ := slices.BinarySearch(r.uris, resource.URI)
+		r.uris = slices.Insert(r.uris, i, resource.URI)
+	}
+	r.resources[resource.URI] = &registryEntry{resource: resource, read: read}
+	return nil
+}
+
+// Unregister removes the resource with the given URI and reports whether it
+// was registered.
+func (r *ResourceRegistry) Unregister(uri string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.resources[uri]; !ok {
+		return false
+	}
+	delete(r.resources, uri)
+	if i, found := slices.BinarySearch(r.uris, uri); found {
+		r.uris = slices.Delete(r.uris, i, i+1)
+	}
+	return true
+}
+
+// RegisterTemplate adds a resource template whose reads are served by read,
+// replacing any template with the same URITemplate. The template is
+// validated, including its RFC 6570 syntax.
+func (r *ResourceRegistry) RegisterTemplate(template *UIResourceTemplate, read TemplateReadFunc) error {
+	if template == nil {
+		return errors.New("template is required")
+	}
+	if read == nil {
+		return errors.New("read function is required")
+	}
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	parsed, err := template.Parse()
+	if err != nil {
+		return err
+	}
+	tmpl := *template
+	entry := &templateEntry{template: &tmpl, parsed: parsed, read: read}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.templates {
+		if e.template.URITemplate == tmpl.URITemplate {
+			r.templates[i] = entry
+			return nil
+		}
+	}
+	r.templates = append(r.templates, entry)
+	return nil
+}
+
+// UnregisterTemplate removes the template with the given URI template and
+// reports whether it was registered.
+func (r *ResourceRegistry) UnregisterTemplate(uriTemplate string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.templates {
+		if e.template.URITemplate == uriTemplate {
+			r.templates = slices.Delete(r.templates, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// Resource returns the registered resource with the given URI.
+func (r *ResourceRegistry) Resource(uri string) (*UIResource, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.resources[uri]
+	if !ok {
+		return nil, false
+	}
+	res := *e.resource
+	return &res, true
+}
+
+// Len returns the number of registered resources.
+func (r *ResourceRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.uris)
+}
+
+// List returns one page of resources. Pass "" for the first page and the
+// previous result's NextCursor for the following ones; NextCursor is empty
+// on the last page. A cursor that was not issued by this registry returns
+// an error wrapping [ErrInvalidCursor].
+//
+// Cursors record the last URI returned, so resources registered or removed
+// between calls neither repeat nor shift pages.
+func (r *ResourceRegistry) List(ctx context.Context, cursor string) (*ListUIResourcesResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	start, end, err := r.page(cursorResources, cursor, r.uris)
+	if err != nil {
+		return nil, err
+	}
+	result := &ListUIResourcesResult{Resources: make([]*UIResource, 0, end-start)}
+	for _, uri := range r.uris[start:end] {
+		res := *r.resources[uri].resource
+		result.Resources = append(result.Resources, &res)
+	}
+	if end < len(r.uris) {
+		result.NextCursor = r.encodeCursor(cursorResources, r.uris[end-1])
+	}
+	return result, nil
+}
+
+// ListTemplates returns one page of resource templates, sorted by
+// URITemplate. Cursors work as for [ResourceRegistry.List].
+func (r *ResourceRegistry) ListTemplates(ctx context.Context, cursor string) (*ListUIResourceTemplatesResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byKey := make(map[string]*UIResourceTemplate, len(r.templates))
+	for _, e := range r.templates {
+		byKey[e.template.URITemplate] = e.template
+	}
+	keys := sortedKeys(byKey)
+	start, end, err := r.page(cursorTemplates, cursor, keys)
+	if err != nil {
+		return nil, err
+	}
+	result := &ListUIResourceTemplatesResult{ResourceTemplates: make([]*UIResourceTemplate, 0, end-start)}
+	for _, key := range keys[start:end] {
+		tmpl := *byKey[key]
+		result.ResourceTemplates = append(result.ResourceTemplates, &tmpl)
+	}
+	if end < len(keys) {
+		result.NextCursor = r.encodeCursor(cursorTemplates, keys[end-1])
+	}
+	return result, nil
+}
+
+// page returns the bounds of the page of sorted keys following cursor.
+func (r *ResourceRegistry) page(kind, cursor string, keys []string) (start, end int, err error) {
+	if cursor != "" {
+		after, err := r.decodeCursor(kind, cursor)
+		if err != nil {
+			return 0, 0, err
+		}
+		start, _ = slices.BinarySearch(keys, after)
+		if start < len(keys) && keys[start] == after {
+			start++
+		}
+	}
+	return start, min(start+r.pageSize, len(keys)), nil
+}
+
+// Read returns the contents of the resource with the given URI. Registered
+// resources take precedence over templates. The error wraps
+// [ErrResourceNotFound] if neither a resource nor a template matches uri.
+func (r *ResourceRegistry) Read(ctx context.Context, uri string) (*ReadUIResourceResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	read, err := r.lookup(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call read without holding the lock so it may use the registry.
+	content, err := read(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := NewUIResourceContents(uri, content)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadUIResourceResult{Contents: []*UIResourceContents{rc}}, nil
+}
+
+// lookup returns the read function serving uri.
+func (r *ResourceRegistry) lookup(uri string) (ResourceReadFunc, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if e, ok := r.resources[uri]; ok {
+		return e.read, nil
+	}
+	for _, e := range r.templates {
+		if vars, ok := e.parsed.Match(uri); ok {
+			read := e.read
+			return func(ctx context.Context, uri string) (UIContent, error) {
+				return read(ctx, uri, vars)
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+}
+
+// Cursor kinds, signed into each cursor so that a resource cursor cannot be
+// used to page templates and vice versa.
+const (
+	cursorResources = "resources"
+	cursorTemplates = "templates"
+)
+
+// encodeCursor returns a signed cursor pointing after key.
+func (r *ResourceRegistry) encodeCursor(kind, key string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(key)) + "." + enc.EncodeToString(r.sign(kind, key))
+}
+
+// decodeCursor verifies a cursor and returns the key it points after.
+func (r *ResourceRegistry) decodeCursor(kind, cursor string) (string, error) {
+	enc := base64.RawURLEncoding
+	data, sig, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return "", ErrInvalidCursor
+	}
+	uri, err := enc.DecodeString(data)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	mac, err := enc.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, r.sign(kind, string(uri))) {
+		return "", ErrInvalidCursor
+	}
+	return string(uri), nil
+}
+
+func (r *ResourceRegistry) sign(kind, key string) []byte {
+	h := hmac.New(sha256.New, r.key)
+	h.Write([]byte("mcpui-cursor\x00" + kind + "\x00"))
+	h.Write([]byte(key))
+	return h.Sum(nil)
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRegistry_Read(t *testing.T) {
+	r := NewResourceRegistry()
+	ctx := context.Background()
+
+	require.NoError(t, r.Register(&UIResource{URI: "ui://static", Name: "static"}, &HTMLContent{HTML: "<p>static</p>"}))
+	calls := 0
+	require.NoError(t, r.RegisterFunc(&UIResource{URI: "ui://dynamic", Name: "dynamic"}, func(ctx context.Context, uri string) (UIContent, error) {
+		calls++
+		return &HTMLContent{HTML: fmt.Sprintf("<p>%s #%d</p>", uri, calls)}, nil
+	}))
+	require.NoError(t, r.RegisterFunc(&UIResource{URI: "ui://broken", Name: "broken"}, func(context.Context, string) (UIContent, error) {
+		return nil, errors.New("backend down")
+	}))
+
+	res, ok := r.Resource("ui://static")
+	require.True(t, ok)
+	assert.Equal(t, MIMETypeHTML, res.MIMEType)
+
+	result, err := r.Read(ctx, "ui://static")
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "<p>static</p>", result.Contents[0].Text)
+
+	result, err = r.Read(ctx, "ui://dynamic")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>ui://dynamic #1</p>", result.Contents[0].Text)
+	result, err = r.Read(ctx, "ui://dynamic")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>ui://dynamic #2</p>", result.Contents[0].Text)
+
+	_, err = r.Read(ctx, "ui://broken")
+	assert.ErrorContains(t, err, "backend down")
+
+	_, err = r.Read(ctx, "ui://missing")
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+
+	assert.True(t, r.Unregister("ui://static"))
+	assert.False(t, r.Unregister("ui://static"))
+	_, err = r.Read(ctx, "ui://static")
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+	assert.Equal(t, 2, r.Len())
+}
+
+func TestResourceRegistry_RegisterErrors(t *testing.T) {
+	r := NewResourceRegistry()
+	assert.Error(t, r.Register(&UIResource{URI: "https://x", Name: "x"}, &HTMLContent{}))
+	assert.Error(t, r.Register(&UIResource{URI: "ui://x", Name: "x"}, nil))
+	assert.Error(t, r.RegisterFunc(&UIResource{URI: "ui://x", Name: "x"}, nil))
+	assert.Error(t, r.Register(&UIResource{URI: "ui://x"}, &HTMLContent{}))
+	assert.EqualError(t, r.Register(nil, &HTMLContent{}), "resource is required")
+	assert.EqualError(t, r.RegisterFunc(nil, func(context.Context, string) (UIContent, error) { return nil, nil }), "resource is required")
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestResourceRegistry_List(t *testing.T) {
+	r := NewResourceRegistry()
+	r.SetPageSize(2)
+	ctx := context.Background()
+	for _, name := range []string{"e", "a", "d", "b", "c"} {
+		require.NoError(t, r.Register(&UIResource{URI: "ui://" + name, Name: name}, &HTMLContent{HTML: name}))
+	}
+
+	var uris []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5)
+		page, err := r.List(ctx, cursor)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(page.Resources), 2)
+		for _, res := range page.Resources {
+			uris = append(uris, res.URI)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"ui://a", "ui://b", "ui://c", "ui://d", "ui://e"}, uris)
+
+	// Cursors survive changes to the registry.
+	first, err := r.List(ctx, "")
+	require.NoError(t, err)
+	require.True(t, r.Unregister("ui://b"))
+	require.NoError(t, r.Register(&UIResource{URI: "ui://bb", Name: "bb"}, &HTMLContent{}))
+	second, err := r.List(ctx, first.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "ui://bb", second.Resources[0].URI)
+
+	r.SetPageSize(0)
+	all, err := r.List(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, all.Resources, 5)
+	assert.Empty(t, all.NextCursor)
+}
+
+func TestResourceRegistry_Cursor(t *testing.T) {
+	r := NewResourceRegistry()
+	r.SetPageSize(1)
+	ctx := context.Background()
+	require.NoError(t, r.Register(&UIResource{URI: "ui://a", Name: "a"}, &HTMLContent{}))
+	require.NoError(t, r.Register(&UIResource{URI: "ui://b", Name: "b"}, &HTMLContent{}))
+
+	page, err := r.List(ctx, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+	assert.NotContains(t, page.NextCursor, "ui://")
+
+	forged := page.NextCursor[:len(page.NextCursor)-4] + "AAAA"
+	for _, cursor := range []string{"garbage", "!!.!!", "dWk6Ly9h.AAAA", forged} {
+		_, err := r.List(ctx, cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+
+	// A cursor from another registry is rejected unless keys are shared.
+	other := NewResourceRegistry()
+	_, err = other.List(ctx, page.NextCursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	r.SetCursorKey([]byte("shared"))
+	other.SetCursorKey([]byte("shared"))
+	page, err = r.List(ctx, "")
+	require.NoError(t, err)
+	_, err = other.List(ctx, page.NextCursor)
+	assert.NoError(t, err)
+}
+
+func TestResourceRegistry_Concurrent(t *testing.T) {
+	r := NewResourceRegistry()
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			uri := fmt.Sprintf("ui://r%02d", i)
+			assert.NoError(t, r.Register(&UIResource{URI: uri, Name: uri}, &HTMLContent{HTML: uri}))
+			_, err := r.Read(ctx, uri)
+			assert.NoError(t, err)
+			_, err = r.List(ctx, "")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 20, r.Len())
+}
+
+func TestResourceRegistry_Templates(t *testing.T) {
+	r := NewResourceRegistry()
+	ctx := context.Background()
+
+	require.NoError(t, r.Register(&UIResource{URI: "ui://orders/special", Name: "special"}, &HTMLContent{HTML: "special"}))
+	require.NoError(t, r.RegisterTemplate(
+		&UIResourceTemplate{URITemplate: "ui://orders/{id}{?view}", Name: "order", MIMEType: MIMETypeHTML},
+		func(ctx context.Context, uri string, vars map[string]string) (UIContent, error) {
+			return &HTMLContent{HTML: "order " + vars["id"] + " " + vars["view"]}, nil
+		}))
+
+	result, err := r.Read(ctx, "ui://orders/42?view=full")
+	require.NoError(t, err)
+	assert.Equal(t, "ui://orders/42?view=full", result.Contents[0].URI)
+	assert.Equal(t, "order 42 full", result.Contents[0].Text)
+
+	result, err = r.Read(ctx, "ui://orders/special")
+	require.NoError(t, err)
+	assert.Equal(t, "special", result.Contents[0].Text, "resources take precedence over templates")
+
+	_, err = r.Read(ctx, "ui://orders/42/items")
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+
+	assert.Error(t, r.RegisterTemplate(&UIResourceTemplate{URITemplate: "ui://bad/{id", Name: "bad"},
+		func(context.Context, string, map[string]string) (UIContent, error) { return nil, nil }))
+	assert.Error(t, r.RegisterTemplate(&UIResourceTemplate{URITemplate: "ui://x/{id}", Name: "x"}, nil))
+	assert.EqualError(t, r.RegisterTemplate(nil,
+		func(context.Context, string, map[string]string) (UIContent, error) { return nil, nil }), "template is required")
+
+	assert.True(t, r.UnregisterTemplate("ui://orders/{id}{?view}"))
+	assert.False(t, r.UnregisterTemplate("ui://orders/{id}{?view}"))
+	_, err = r.Read(ctx, "ui://orders/42")
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+}
+
+func TestResourceRegistry_ListTemplates(t *testing.T) {
+	r := NewResourceRegistry()
+	r.SetPageSize(2)
+	ctx := context.Background()
+	read := func(context.Context, string, map[string]string) (UIContent, error) { return &HTMLContent{}, nil }
+	for _, name := range []string{"c", "a", "b"} {
+		require.NoError(t, r.RegisterTemplate(&UIResourceTemplate{URITemplate: "ui://" + name + "/{id}", Name: name}, read))
+	}
+	require.NoError(t, r.Register(&UIResource{URI: "ui://a/x", Name: "x"}, &HTMLContent{}))
+	require.NoError(t, r.Register(&UIResource{URI: "ui://b/x", Name: "y"}, &HTMLContent{}))
+	require.NoError(t, r.Register(&UIResource{URI: "ui://c/x", Name: "z"}, &HTMLContent{}))
+
+	page, err := r.ListTemplates(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, page.ResourceTemplates, 2)
+	assert.Equal(t, "ui://a/{id}", page.ResourceTemplates[0].URITemplate)
+	assert.Equal(t, "ui://b/{id}", page.ResourceTemplates[1].URITemplate)
+
+	next, err := r.ListTemplates(ctx, page.NextCursor)
+	require.NoError(t, err)
+	require.Len(t, next.ResourceTemplates, 1)
+	assert.Equal(t, "ui://c/{id}", next.ResourceTemplates[0].URITemplate)
+	assert.Empty(t, next.NextCursor)
+
+	// Cursors are not interchangeable between resources and templates.
+	_, err = r.List(ctx, page.NextCursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RemoteNode is a node in a Remote DOM tree: a [*RemoteElement] or a [RemoteText].
+type RemoteNode interface {
+	// writeScript emits statements that create the node and append it to
+	// the element held in the JavaScript variable parent.
+	writeScript(w *remoteScriptWriter, parent string) error
+}
+
+// RemoteText is a text node in a Remote DOM tree.
+type RemoteText string
+
+// RemoteElement is an element in a Remote DOM tree, typically one of the
+// host's remote components such as "ui-button" or "ui-stack".
+//
+// Build trees with [NewRemoteElement] and turn them into a script with
+// [NewRemoteDOMContent]:
+//
+//	action, _ := mcpui.NewToolAction("", "refresh", nil)
+//	tree := mcpui.NewRemoteElement("ui-stack",
+//		mcpui.NewRemoteElement("ui-text").SetAttribute("content", "Status: OK"),
+//		mcpui.NewRemoteElement("ui-button").
+//			SetAttribute("label", "Refresh").
+//			On("press", action),
+//	)
+//	content, err := mcpui.NewRemoteDOMContent(mcpui.FrameworkReact, tree)
+type RemoteElement struct {
+	// Tag is the element name.
+	Tag string
+	// Attributes holds attribute values. Strings, numbers and booleans are
+	// set as attributes; other values are JSON-encoded and set as properties.
+	Attributes map[string]any
+	// Events maps DOM event names to the action posted to the host when
+	// the event fires.
+	Events map[string]*UIAction
+	// Children are the element's child nodes, in order.
+	Children []RemoteNode
+}
+
+// NewRemoteElement creates an element with the given children.
+func NewRemoteElement(tag string, children ...RemoteNode) *RemoteElement {
+	return &RemoteElement{Tag: tag, Children: children}
+}
+
+// SetAttribute sets an attribute value and returns the element.
+func (e *RemoteElement) SetAttribute(name string, value any) *RemoteElement {
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]any)
+	}
+	e.Attributes[name] = value
+	return e
+}
+
+// On binds a DOM event to a UI action and returns the element.
+// When the event fires, the action is posted to the host exactly as if the
+// script had called window.parent.postMessage with it.
+func (e *RemoteElement) On(event string, action *UIAction) *RemoteElement {
+	if e.Events == nil {
+		e.Events = make(map[string]*UIAction)
+	}
+	e.Events[event] = action
+	return e
+}
+
+// Append adds child nodes and returns the element.
+func (e *RemoteElement) Append(children ...RemoteNode) *RemoteElement {
+	e.Children = append(e.Children, children...)
+	return e
+}
+
+// NewRemoteDOMContent renders Remote DOM trees into a [RemoteDOMContent]
+// script. The top-level nodes are appended to the script's root element.
+//
+// The generated script only uses the DOM API exposed to remote-dom scripts
+// (document.createElement, setAttribute, addEventListener, appendChild), so
+// the same script is valid for [FrameworkReact] and [FrameworkWebComponents];
+// the framework selects the host-side renderer for the remote components.
+// Use [ComponentLibrary.NewRemoteDOMContent] to also check the tree against
+// the components the host provides.
+func NewRemoteDOMContent(framework Framework, nodes ...RemoteNode) (*RemoteDOMContent, error) {
+	if err := framework.Validate(); err != nil {
+		return nil, err
+	}
+	script, err := RemoteDOMScript(nodes...)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteDOMContent{Script: script, Framework: framework}, nil
+}
+
+// RemoteDOMScript renders Remote DOM trees into JavaScript that appends
+// them to the script's root element.
+func RemoteDOMScript(nodes ...RemoteNode) (string, error) {
+	w := &remoteScriptWriter{}
+	for _, n := range nodes {
+		if n == nil {
+			return "", errors.New("nil remote DOM node")
+		}
+		if err := n.writeScript(w, "root"); err != nil {
+			return "", err
+		}
+	}
+	return w.b.String(), nil
+}
+
+// remoteScriptWriter accumulates generated statements and allocates
+// variable names.
+type remoteScriptWriter struct {
+	b    strings.Builder
+	next int
+}
+
+func (w *remoteScriptWriter) newVar() string {
+	name := "el" + strconv.Itoa(w.next)
+	w.next++
+	return name
+}
+
+func (w *remoteScriptWriter) printf(format string, args ...any) {
+	fmt.Fprintf(&w.b, format, args...)
+	w.b.WriteByte('\n')
+}
+
+func (t RemoteText) writeScript(w *remoteScriptWriter, parent string) error {
+	w.printf("%s.appendChild(document.createTextNode(%s));", parent, jsString(string(t)))
+	return nil
+}
+
+func (e *RemoteElement) writeScript(w *remoteScriptWriter, parent string) error {
+	if e == nil {
+		return errors.New("nil remote DOM element")
+	}
+	if !validRemoteName(e.Tag) {
+		return fmt.Errorf("invalid remote element tag %q", e.Tag)
+	}
+	v := w.newVar()
+	w.printf("const %s = document.createElement(%s);", v, jsString(e.Tag))
+
+	for _, name := range sortedKeys(e.Attributes) {
+		if !validRemoteName(name) {
+			return fmt.Errorf("invalid attribute name %q on <%s>", name, e.Tag)
+		}
+		switch val := e.Attributes[name].(type) {
+		case string:
+			w.printf("%s.setAttribute(%s, %s);", v, jsString(name), jsString(val))
+		case bool:
+			if val {
+				w.printf("%s.setAttribute(%s, \"\");", v, jsString(name))
+			}
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			w.printf("%s.setAttribute(%s, %s);", v, jsString(name), jsString(fmt.Sprint(val)))
+		default:
+			data, err := json.Marshal(val)
+			if err != nil {
+				return fmt.Errorf("attribute %q on <%s>: %w", name, e.Tag, err)
+			}
+			w.printf("%s[%s] = %s;", v, jsString(name), data)
+		}
+	}
+
+	for _, event := range sortedKeys(e.Events) {
+		if !validRemoteName(event) {
+			return fmt.Errorf("invalid event name %q on <%s>", event, e.Tag)
+		}
+		action := e.Events[event]
+		if action == nil {
+			return fmt.Errorf("nil action for event %q on <%s>", event, e.Tag)
+		}
+		data, err := json.Marshal(action)
+		if err != nil {
+			return fmt.Errorf("action for event %q on <%s>: %w", event, e.Tag, err)
+		}
+		w.printf("%s.addEventListener(%s, () => { window.parent.postMessage(%s, \"*\"); });", v, jsString(event), data)
+	}
+
+	for _, child := range e.Children {
+		if child == nil {
+			return fmt.Errorf("nil child of <%s>", e.Tag)
+		}
+		if err := child.writeScript(w, v); err != nil {
+			return err
+		}
+	}
+	w.printf("%s.appendChild(%s);", parent, v)
+	return nil
+}
+
+// jsString returns s as a JavaScript string literal. JSON string encoding
+// is valid JavaScript, and encoding/json escapes <, > and & so the literal
+// cannot terminate an enclosing <script> element.
+func jsString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// validRemoteName reports whether s is usable as an element, attribute or
+// event name: a letter followed by letters, digits, '-', '_', ':' or '.'.
+func validRemoteName(s string) bool {
+	if s == "" || !isASCIILetter(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if !isASCIILetter(c) && (c < '0' || c > '9') && c != '-' && c != '_' && c != ':' && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
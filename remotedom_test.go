@@ -0,0 +1,86 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteDOMScript(t *testing.T) {
+	action, err := NewToolAction("msg-1", "refresh", map[string]any{"id": 7})
+	require.NoError(t, err)
+
+	tree := NewRemoteElement("ui-stack",
+		NewRemoteElement("ui-text").SetAttribute("content", "Hello </script>"),
+		NewRemoteElement("ui-button").
+			SetAttribute("label", "Refresh").
+			SetAttribute("disabled", false).
+			SetAttribute("size", 2).
+			SetAttribute("style", map[string]any{"gap": "4px"}).
+			On("press", action),
+		RemoteText("tail"),
+	).SetAttribute("direction", "vertical")
+
+	script, err := RemoteDOMScript(tree)
+	require.NoError(t, err)
+
+	want := `const el0 = document.createElement("ui-stack");
+el0.setAttribute("direction", "vertical");
+const el1 = document.createElement("ui-text");
+el1.setAttribute("content", "Hello \u003c/script\u003e");
+el0.appendChild(el1);
+const el2 = document.createElement("ui-button");
+el2.setAttribute("label", "Refresh");
+el2.setAttribute("size", "2");
+el2["style"] = {"gap":"4px"};
+el2.addEventListener("press", () => { window.parent.postMessage({"type":"tool","messageId":"msg-1","payload":{"toolName":"refresh","params":{"id":7}}}, "*"); });
+el0.appendChild(el2);
+el0.appendChild(document.createTextNode("tail"));
+root.appendChild(el0);
+`
+	assert.Equal(t, want, script)
+}
+
+func TestRemoteDOMScript_Errors(t *testing.T) {
+	action, _ := NewPromptAction("", "hi")
+
+	tests := []struct {
+		name string
+		node RemoteNode
+	}{
+		{"empty tag", NewRemoteElement("")},
+		{"invalid tag", NewRemoteElement("ui-button onclick=x")},
+		{"invalid attribute", NewRemoteElement("ui-text").SetAttribute("a\"b", "x")},
+		{"invalid event", NewRemoteElement("ui-button").On("", action)},
+		{"nil action", NewRemoteElement("ui-button").On("press", nil)},
+		{"nil child", NewRemoteElement("ui-stack", nil)},
+		{"unmarshalable attribute", NewRemoteElement("ui-text").SetAttribute("x", func() {})},
+		{"nil element", (*RemoteElement)(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RemoteDOMScript(tt.node)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewRemoteDOMContent(t *testing.T) {
+	for _, fw := range []Framework{FrameworkReact, FrameworkWebComponents} {
+		t.Run(string(fw), func(t *testing.T) {
+			content, err := NewRemoteDOMContent(fw, NewRemoteElement("ui-text", RemoteText("hi")))
+			require.NoError(t, err)
+			assert.Equal(t, fw, content.Framework)
+			assert.Contains(t, content.Script, `root.appendChild(el0);`)
+		})
+	}
+
+	_, err := NewRemoteDOMContent(FrameworkReact, nil)
+	assert.Error(t, err)
+}
@@ -8,9 +8,14 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 )
 
+// ErrResourceNotFound is returned when a requested UI resource does not exist.
+// Errors returned for unknown URIs wrap it, so check with errors.Is.
+var ErrResourceNotFound = errors.New("resource not found")
+
 // UIResource represents an interactive UI resource definition.
 // This mirrors mcp.Resource for UI-specific resources.
 // See https://mcpui.dev/guide/protocol-details
@@ -56,6 +61,8 @@ type UIResourceContents struct {
 	Blob []byte `json:"blob,omitempty"`
 	// Annotations contains optional metadata.
 	Annotations *Annotations `json:"annotations,omitempty"`
+	// Meta holds protocol extension metadata, sent as _meta.
+	Meta map[string]any `json:"_meta,omitempty"`
 }
 
 // MarshalJSON serializes UIResourceContents to JSON.
@@ -76,19 +83,26 @@ func (r *UIResourceContents) MarshalJSON() ([]byte, error) {
 	// r.Blob may be the empty slice, so marshal with an alternative definition
 	// to ensure "blob" is always included.
 	br := struct {
-		URI         string       `json:"uri,omitempty"`
-		MIMEType    string       `json:"mimeType,omitempty"`
-		Blob        []byte       `json:"blob"`
-		Annotations *Annotations `json:"annotations,omitempty"`
+		URI         string         `json:"uri,omitempty"`
+		MIMEType    string         `json:"mimeType,omitempty"`
+		Blob        []byte         `json:"blob"`
+		Annotations *Annotations   `json:"annotations,omitempty"`
+		Meta        map[string]any `json:"_meta,omitempty"`
 	}{
 		URI:         r.URI,
 		MIMEType:    r.MIMEType,
 		Blob:        r.Blob,
 		Annotations: r.Annotations,
+		Meta:        r.Meta,
 	}
 	return json.Marshal(br)
 }
 
+// MediaType parses the MIMEType field, exposing its parameters.
+func (r *UIResourceContents) MediaType() (MediaType, error) {
+	return ParseMediaType(r.MIMEType)
+}
+
 // NewUIResourceContents creates UIResourceContents from a UIContent.
 func NewUIResourceContents(uri string, content UIContent) (*UIResourceContents, error) {
 	if uri == "" {
@@ -113,6 +127,7 @@ func NewUIResourceContents(uri string, content UIContent) (*UIResourceContents,
 		URI:         uri,
 		MIMEType:    wire.MIMEType,
 		Annotations: wire.Annotations,
+		Meta:        wire.Meta,
 	}
 
 	if wire.Blob != "" {
@@ -135,6 +150,7 @@ func (r *UIResourceContents) ToUIContent() (UIContent, error) {
 		MIMEType:    r.MIMEType,
 		Text:        r.Text,
 		Annotations: r.Annotations,
+		Meta:        r.Meta,
 	}
 	if r.Blob != nil {
 		// Encode blob to base64 string for wire format
@@ -171,9 +187,17 @@ func (t *UIResourceTemplate) Validate() error {
 	if t.Name == "" {
 		return errors.New("UIResourceTemplate missing Name")
 	}
+	if _, err := ParseURITemplate(t.URITemplate); err != nil {
+		return fmt.Errorf("UIResourceTemplate: %w", err)
+	}
 	return nil
 }
 
+// Parse parses the URITemplate field.
+func (t *UIResourceTemplate) Parse() (*URITemplate, error) {
+	return ParseURITemplate(t.URITemplate)
+}
+
 // ReadUIResourceResult is the result of reading a UI resource.
 // This mirrors mcp.ReadResourceResult.
 type ReadUIResourceResult struct {
@@ -181,6 +205,15 @@ type ReadUIResourceResult struct {
 	Contents []*UIResourceContents `json:"contents"`
 }
 
+// ListUIResourceTemplatesResult is the result of listing UI resource templates.
+// This mirrors mcp.ListResourceTemplatesResult.
+type ListUIResourceTemplatesResult struct {
+	// ResourceTemplates is the list of available UI resource templates.
+	ResourceTemplates []*UIResourceTemplate `json:"resourceTemplates"`
+	// NextCursor is an opaque token for pagination.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
 // ListUIResourcesResult is the result of listing UI resources.
 // This mirrors mcp.ListResourcesResult.
 type ListUIResourcesResult struct {
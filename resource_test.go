@@ -316,6 +316,30 @@ func TestUIResourceTemplate_Validate(t *testing.T) {
 			},
 			wantErr: "missing Name",
 		},
+		{
+			name: "unclosed expression",
+			template: &UIResourceTemplate{
+				URITemplate: "ui://dashboard/{id",
+				Name:        "Dashboard",
+			},
+			wantErr: "unclosed expression",
+		},
+		{
+			name: "invalid variable name",
+			template: &UIResourceTemplate{
+				URITemplate: "ui://dashboard/{dash-id}",
+				Name:        "Dashboard",
+			},
+			wantErr: "invalid variable name",
+		},
+		{
+			name: "level 4 template",
+			template: &UIResourceTemplate{
+				URITemplate: "ui://files{/path*}{?q,page}",
+				Name:        "Files",
+			},
+			wantErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,414 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// SanitizePolicy is an allowlist describing which HTML elements, attributes
+// and URL schemes survive sanitization.
+//
+// Regardless of policy, the sanitizer always removes <script> elements,
+// event handler attributes (onclick, onload, ...), <base> elements, HTML
+// comments, and URL attributes whose scheme is not listed in URLSchemes
+// (for example javascript: and vbscript: URLs). Disallowed elements are
+// removed but their text content is kept, except for elements such as
+// <iframe>, <object> and <template> whose content is dropped entirely.
+// Allowed raw text elements such as <style> and <title> are dropped inside
+// <select>, <svg> and <math>, where browsers parse their content as
+// markup, and their content is escaped or dropped if it contains "<".
+//
+// Start with [StrictTextPolicy], [RichTextPolicy] or [FullAppPolicy] and
+// customize with [SanitizePolicy.AllowElement]. Each call to these
+// constructors returns a fresh policy that may be modified freely.
+type SanitizePolicy struct {
+	// Elements maps each allowed element name to its allowed attributes.
+	Elements map[string][]string
+	// GlobalAttributes are allowed on every allowed element. An entry ending
+	// in "*" (such as "data-*") allows every attribute with that prefix.
+	GlobalAttributes []string
+	// URLSchemes lists the schemes allowed in URL attributes (href, src,
+	// action, ...). Relative URLs are always allowed.
+	URLSchemes []string
+	// AllowDataImages permits data:image/ URLs in <img src>.
+	AllowDataImages bool
+}
+
+// dropContentElements are elements whose entire content is removed when the
+// element itself is not allowed.
+var dropContentElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"applet":   true,
+	"template": true,
+	"noscript": true,
+	"frameset": true,
+	"frame":    true,
+	"svg":      true,
+	"math":     true,
+}
+
+// neverAllowedElements are removed even when a policy lists them.
+var neverAllowedElements = map[string]bool{
+	"script": true,
+	"base":   true,
+}
+
+// documentElements are only kept when sanitizing whole documents.
+var documentElements = map[string]bool{
+	"html":  true,
+	"head":  true,
+	"body":  true,
+	"meta":  true,
+	"title": true,
+	"link":  true,
+}
+
+// voidElements never have content or an end tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"frame":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// markupContexts are elements inside which browsers parse raw text
+// elements such as <style> and <title> as markup, unlike the tokenizer.
+// Raw text elements are never kept inside them.
+var markupContexts = map[string]bool{
+	"select": true,
+	"svg":    true,
+	"math":   true,
+}
+
+// escapableRawTextElements are raw text elements whose content is decoded
+// as text, so it can be escaped like text.
+var escapableRawTextElements = map[string]bool{
+	"textarea": true,
+	"title":    true,
+}
+
+// urlAttributes are attributes whose values are interpreted as URLs.
+var urlAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"poster":     true,
+	"cite":       true,
+	"data":       true, // <object data>
+	"background": true,
+	"longdesc":   true,
+	"ping":       true,
+	"xlink:href": true,
+}
+
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// StrictTextPolicy returns a policy that removes all markup and keeps only text.
+func StrictTextPolicy() *SanitizePolicy {
+	return &SanitizePolicy{
+		Elements:   map[string][]string{},
+		URLSchemes: []string{"http", "https"},
+	}
+}
+
+// RichTextPolicy returns a policy for user-generated formatted text:
+// paragraphs, emphasis, lists, headings, links, tables and images.
+func RichTextPolicy() *SanitizePolicy {
+	p := &SanitizePolicy{
+		Elements:         map[string][]string{},
+		GlobalAttributes: []string{"title", "lang", "dir"},
+		URLSchemes:       []string{"http", "https", "mailto"},
+	}
+	for _, name := range []string{
+		"p", "br", "hr", "b", "strong", "i", "em", "u", "s", "sub", "sup", "small", "mark",
+		"code", "pre", "kbd", "span", "div", "ul", "li", "dl", "dt", "dd",
+		"h1", "h2", "h3", "h4", "h5", "h6",
+		"table", "thead", "tbody", "tfoot", "tr", "caption",
+	} {
+		p.AllowElement(name)
+	}
+	p.AllowElement("a", "href", "target", "rel")
+	p.AllowElement("blockquote", "cite")
+	p.AllowElement("ol", "start", "reversed")
+	p.AllowElement("th", "colspan", "rowspan", "scope")
+	p.AllowElement("td", "colspan", "rowspan")
+	p.AllowElement("img", "src", "alt", "width", "height")
+	return p
+}
+
+// FullAppPolicy returns a policy for complete, script-free UI documents.
+// It extends [RichTextPolicy] with document structure, inline styles,
+// stylesheets, forms and media, and allows class, id, style, role, aria-*
+// and data-* attributes on every element.
+func FullAppPolicy() *SanitizePolicy {
+	p := RichTextPolicy()
+	p.GlobalAttributes = append(p.GlobalAttributes, "class", "id", "style", "role", "hidden", "tabindex", "aria-*", "data-*")
+	p.AllowDataImages = true
+	for _, name := range []string{
+		"html", "head", "body", "title", "style",
+		"section", "article", "header", "footer", "nav", "main", "aside",
+		"figure", "figcaption", "details", "summary", "fieldset", "legend",
+		"abbr", "time", "colgroup", "col",
+	} {
+		p.AllowElement(name)
+	}
+	p.AllowElement("meta", "charset", "name", "content")
+	p.AllowElement("link", "rel", "href", "media", "type")
+	p.AllowElement("form", "action", "method", "name", "novalidate")
+	p.AllowElement("label", "for")
+	p.AllowElement("input", "type", "name", "value", "placeholder", "checked", "disabled", "readonly", "required", "min", "max", "step", "pattern", "maxlength")
+	p.AllowElement("button", "type", "name", "value", "disabled")
+	p.AllowElement("select", "name", "multiple", "disabled", "required")
+	p.AllowElement("option", "value", "selected", "disabled")
+	p.AllowElement("optgroup", "label", "disabled")
+	p.AllowElement("textarea", "name", "rows", "cols", "placeholder", "disabled", "readonly", "required", "maxlength")
+	p.AllowElement("progress", "value", "max")
+	p.AllowElement("meter", "value", "min", "max", "low", "high", "optimum")
+	p.AllowElement("canvas", "width", "height")
+	p.AllowElement("video", "src", "poster", "controls", "width", "height", "loop", "muted")
+	p.AllowElement("audio", "src", "controls", "loop", "muted")
+	p.AllowElement("source", "src", "type")
+	return p
+}
+
+// AllowElement allows the named element with the given attributes, adding to
+// any attributes already allowed for it.
+func (p *SanitizePolicy) AllowElement(name string, attrs ...string) *SanitizePolicy {
+	if p.Elements == nil {
+		p.Elements = map[string][]string{}
+	}
+	name = strings.ToLower(name)
+	existing := p.Elements[name]
+	for _, a := range attrs {
+		a = strings.ToLower(a)
+		if !slices.Contains(existing, a) {
+			existing = append(existing, a)
+		}
+	}
+	if existing == nil {
+		existing = []string{}
+	}
+	p.Elements[name] = existing
+	return p
+}
+
+// Sanitize sanitizes a complete HTML document. Document-level elements
+// (<html>, <head>, <body>, <meta>, ...) and the doctype are kept when the
+// policy allows them.
+func (p *SanitizePolicy) Sanitize(doc string) string {
+	return p.sanitize(doc, false)
+}
+
+// SanitizeFragment sanitizes an HTML fragment that will be spliced into a
+// larger template. Document-level elements are always removed, and the
+// output is balanced: stray end tags are dropped and unclosed elements are
+// closed, so the fragment cannot break out of its surrounding markup.
+func (p *SanitizePolicy) SanitizeFragment(fragment string) string {
+	return p.sanitize(fragment, true)
+}
+
+// SanitizeContent returns a copy of content with its HTML sanitized as a
+// complete document.
+func (p *SanitizePolicy) SanitizeContent(content *HTMLContent) *HTMLContent {
+	if content == nil {
+		return nil
+	}
+	out := *content
+	out.HTML = p.Sanitize(content.HTML)
+	return &out
+}
+
+func (p *SanitizePolicy) sanitize(src string, fragment bool) string {
+	tokens := tokenizeHTML(src)
+	var b strings.Builder
+	var open []string
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok.Type {
+		case htmlText:
+			b.WriteString(textEscaper.Replace(html.UnescapeString(tok.Raw)))
+		case htmlDoctype:
+			if !fragment && strings.HasPrefix(strings.ToLower(tok.Data), "doctype") {
+				b.WriteString("<!DOCTYPE html>")
+			}
+		case htmlStartTag, htmlSelfClosingTag:
+			allowed := p.allowsElement(tok.Data, fragment)
+			if tok.Type == htmlStartTag && rawTextElements[tok.Data] {
+				// The tokenizer returns raw text content as the next token.
+				var body string
+				if i+1 < len(tokens) && tokens[i+1].Type == htmlText {
+					body = tokens[i+1].Raw
+					i++
+				}
+				if allowed && !slices.ContainsFunc(open, func(name string) bool { return markupContexts[name] }) {
+					b.WriteString(p.sanitizeTag(tok).String())
+					b.WriteString(sanitizeRawText(tok.Data, body))
+					open = append(open, tok.Data)
+				}
+				continue
+			}
+			if !allowed {
+				// Void elements have no content to skip.
+				if tok.Type == htmlStartTag && dropContentElements[tok.Data] && !voidElements[tok.Data] {
+					i = skipElement(tokens, i)
+				}
+				continue
+			}
+			b.WriteString(p.sanitizeTag(tok).String())
+			if tok.Type == htmlStartTag && !voidElements[tok.Data] {
+				open = append(open, tok.Data)
+			}
+		case htmlEndTag:
+			idx := slices.Index(open, tok.Data)
+			if idx == -1 {
+				continue
+			}
+			for j := len(open) - 1; j >= idx; j-- {
+				b.WriteString("</" + open[j] + ">")
+			}
+			open = open[:idx]
+		}
+	}
+	for j := len(open) - 1; j >= 0; j-- {
+		b.WriteString("</" + open[j] + ">")
+	}
+	return b.String()
+}
+
+// sanitizeRawText returns the content of a kept raw text element. Content
+// that a browser could parse differently from the tokenizer cannot end the
+// element: escapable content is escaped, and other content containing "<"
+// is dropped.
+func sanitizeRawText(elem, body string) string {
+	if escapableRawTextElements[elem] {
+		return textEscaper.Replace(html.UnescapeString(body))
+	}
+	if strings.Contains(body, "<") {
+		return ""
+	}
+	return body
+}
+
+// skipElement returns the index of the end tag matching the start tag at
+// tokens[start], or the last index if the element is never closed.
+func skipElement(tokens []htmlToken, start int) int {
+	name := tokens[start].Data
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		switch {
+		case tokens[i].Type == htmlStartTag && tokens[i].Data == name:
+			depth++
+		case tokens[i].Type == htmlEndTag && tokens[i].Data == name:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+func (p *SanitizePolicy) allowsElement(name string, fragment bool) bool {
+	if neverAllowedElements[name] {
+		return false
+	}
+	if fragment && documentElements[name] {
+		return false
+	}
+	_, ok := p.Elements[name]
+	return ok
+}
+
+// sanitizeTag returns a copy of tok keeping only allowed, safe attributes.
+func (p *SanitizePolicy) sanitizeTag(tok htmlToken) *htmlToken {
+	out := &htmlToken{Type: tok.Type, Data: tok.Data}
+	allowed := p.Elements[tok.Data]
+	for _, a := range tok.Attrs {
+		if !slices.Contains(allowed, a.Key) && !p.allowsGlobalAttr(a.Key) {
+			continue
+		}
+		if strings.HasPrefix(a.Key, "on") || a.Key == "srcdoc" || a.Key == "srcset" {
+			continue
+		}
+		if urlAttributes[a.Key] && !p.allowsURL(tok.Data, a.Key, a.Val) {
+			continue
+		}
+		if a.Key == "style" && !safeStyle(a.Val) {
+			continue
+		}
+		out.Attrs = append(out.Attrs, a)
+	}
+	if tok.Data == "a" {
+		if target, ok := out.attr("target"); ok && target == "_blank" {
+			out.setAttr("rel", "noopener noreferrer")
+		}
+	}
+	return out
+}
+
+func (p *SanitizePolicy) allowsGlobalAttr(key string) bool {
+	for _, g := range p.GlobalAttributes {
+		if prefix, ok := strings.CutSuffix(g, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if g == key {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsURL reports whether a URL attribute value uses an allowed scheme.
+func (p *SanitizePolicy) allowsURL(elem, attr, val string) bool {
+	// Browsers ignore ASCII whitespace and control characters inside the
+	// scheme, so "java\tscript:" must be treated as "javascript:".
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, val)
+	colon := strings.IndexByte(cleaned, ':')
+	if colon == -1 || strings.ContainsAny(cleaned[:colon], "/?#") {
+		return true // relative URL
+	}
+	scheme := strings.ToLower(cleaned[:colon])
+	if scheme == "data" {
+		return p.AllowDataImages && elem == "img" && attr == "src" &&
+			strings.HasPrefix(strings.ToLower(cleaned), "data:image/")
+	}
+	return slices.Contains(p.URLSchemes, scheme)
+}
+
+// safeStyle rejects inline styles containing script-capable constructs.
+func safeStyle(style string) bool {
+	lower := strings.ToLower(strings.Join(strings.Fields(style), ""))
+	for _, bad := range []string{"expression(", "javascript:", "vbscript:", "-moz-binding", "behavior:"} {
+		if strings.Contains(lower, bad) {
+			return false
+		}
+	}
+	return true
+}
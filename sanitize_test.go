@@ -0,0 +1,214 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictTextPolicy(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "tags removed, text kept",
+			input: "<p>Hello <b>world</b></p>",
+			want:  "Hello world",
+		},
+		{
+			name:  "script content dropped",
+			input: "a<script>alert(1)</script>b",
+			want:  "ab",
+		},
+		{
+			name:  "entities normalized",
+			input: "1 &lt; 2 & 3 > 2",
+			want:  "1 &lt; 2 &amp; 3 &gt; 2",
+		},
+		{
+			name:  "comments dropped",
+			input: "x<!-- secret -->y",
+			want:  "xy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StrictTextPolicy().SanitizeFragment(tt.input))
+		})
+	}
+}
+
+func TestRichTextPolicy(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "formatting kept",
+			input: `<p>Hi <em>there</em></p><ul><li>one</li></ul>`,
+			want:  `<p>Hi <em>there</em></p><ul><li>one</li></ul>`,
+		},
+		{
+			name:  "event handlers removed",
+			input: `<p onclick="steal()" title="t">x</p>`,
+			want:  `<p title="t">x</p>`,
+		},
+		{
+			name:  "javascript URL removed",
+			input: `<a href="javascript:alert(1)">x</a>`,
+			want:  `<a>x</a>`,
+		},
+		{
+			name:  "obfuscated javascript URL removed",
+			input: "<a href=\"jav&#x09;ascript:alert(1)\">x</a>",
+			want:  `<a>x</a>`,
+		},
+		{
+			name:  "safe URL kept",
+			input: `<a href="https://example.com/?a=1&amp;b=2">x</a>`,
+			want:  `<a href="https://example.com/?a=1&amp;b=2">x</a>`,
+		},
+		{
+			name:  "relative URL kept",
+			input: `<a href="/docs#intro">x</a>`,
+			want:  `<a href="/docs#intro">x</a>`,
+		},
+		{
+			name:  "target blank gets rel",
+			input: `<a href="https://example.com" target="_blank">x</a>`,
+			want:  `<a href="https://example.com" target="_blank" rel="noopener noreferrer">x</a>`,
+		},
+		{
+			name:  "disallowed attribute removed",
+			input: `<div class="x" style="color:red">y</div>`,
+			want:  `<div>y</div>`,
+		},
+		{
+			name:  "disallowed element unwrapped",
+			input: `<font color="red">text</font>`,
+			want:  `text`,
+		},
+		{
+			name:  "iframe content dropped",
+			input: `a<iframe src="https://evil.example"><p>fallback</p></iframe>b`,
+			want:  `ab`,
+		},
+		{
+			name:  "svg dropped",
+			input: `<svg><script>alert(1)</script><text>hi</text></svg>ok`,
+			want:  `ok`,
+		},
+		{
+			name:  "data image rejected by default",
+			input: `<img src="data:image/png;base64,AAAA" alt="x">`,
+			want:  `<img alt="x">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RichTextPolicy().SanitizeFragment(tt.input))
+		})
+	}
+}
+
+func TestFullAppPolicy(t *testing.T) {
+	doc := `<!DOCTYPE html><html><head><meta charset="utf-8"><meta http-equiv="refresh" content="0;url=https://evil.example"><base href="https://evil.example/"><style>.a{color:red}</style></head>` +
+		`<body><form action="javascript:go()"><input name="q" onfocus="x()"><button type="submit">Go</button></form>` +
+		`<img src="data:image/png;base64,AAAA"><div data-id="1" aria-label="l" style="background:url(javascript:x)">d</div><script>bad()</script></body></html>`
+
+	got := FullAppPolicy().Sanitize(doc)
+	assert.Equal(t,
+		`<!DOCTYPE html><html><head><meta charset="utf-8"><meta content="0;url=https://evil.example"><style>.a{color:red}</style></head>`+
+			`<body><form><input name="q"><button type="submit">Go</button></form>`+
+			`<img src="data:image/png;base64,AAAA"><div data-id="1" aria-label="l">d</div></body></html>`,
+		got)
+}
+
+func TestFullAppPolicy_RawText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "style inside select",
+			input: `<select><style></select><img src=x onerror=alert(1)></style>`,
+			want:  `<select></select>`,
+		},
+		{
+			name:  "title inside select",
+			input: `<body><select><title></select><img src=x onerror=alert(1)></title></body>`,
+			want:  `<body><select></select></body>`,
+		},
+		{
+			name:  "markup in style dropped",
+			input: `<style>.a{color:red}</style><style>p::after{content:"<img src=x onerror=y>"}</style>`,
+			want:  `<style>.a{color:red}</style><style></style>`,
+		},
+		{
+			name:  "escapable raw text escaped",
+			input: `<textarea>a &amp; <b>b</b></textarea><title>1 < 2</title>`,
+			want:  `<textarea>a &amp; &lt;b&gt;b&lt;/b&gt;</textarea><title>1 &lt; 2</title>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FullAppPolicy().Sanitize(tt.input)
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, got, "<img")
+		})
+	}
+}
+
+func TestSanitizePolicy_ObjectData(t *testing.T) {
+	p := StrictTextPolicy().AllowElement("object", "data", "type")
+	assert.Equal(t, `<object type="text/html"></object>`, p.SanitizeFragment(`<object data="javascript:alert(1)" type="text/html"></object>`))
+	assert.Equal(t, `<object data="https://example.com/a.svg"></object>`, p.SanitizeFragment(`<object data="https://example.com/a.svg"></object>`))
+}
+
+func TestSanitizePolicy_SanitizeFragment(t *testing.T) {
+	t.Run("document elements removed", func(t *testing.T) {
+		got := FullAppPolicy().SanitizeFragment(`<html><head><title>T</title></head><body><p>x</p></body></html>`)
+		assert.Equal(t, `<p>x</p>`, got)
+	})
+
+	t.Run("void elements dropped alone", func(t *testing.T) {
+		got := RichTextPolicy().SanitizeFragment(`<p>a</p><embed src="x"><p>b</p><p>c</p>`)
+		assert.Equal(t, `<p>a</p><p>b</p><p>c</p>`, got)
+
+		got = FullAppPolicy().SanitizeFragment(`<p>a</p><frame src="x"><p>b</p><p>c</p>`)
+		assert.Equal(t, `<p>a</p><p>b</p><p>c</p>`, got)
+	})
+
+	t.Run("balanced output", func(t *testing.T) {
+		got := RichTextPolicy().SanitizeFragment(`</div></td><p><b>bold<i>both</p>tail`)
+		assert.Equal(t, `<p><b>bold<i>both</i></b></p>tail`, got)
+	})
+}
+
+func TestSanitizePolicy_AllowElement(t *testing.T) {
+	p := StrictTextPolicy().AllowElement("span", "class").AllowElement("script")
+	got := p.SanitizeFragment(`<span class="a" id="b">x</span><script>y</script>`)
+	assert.Equal(t, `<span class="a">x</span>`, got)
+}
+
+func TestSanitizePolicy_SanitizeContent(t *testing.T) {
+	content := &HTMLContent{HTML: `<p onclick="x()">hi</p>`, Annotations: &Annotations{Audience: []string{"user"}}}
+	got := RichTextPolicy().SanitizeContent(content)
+	require.NotNil(t, got)
+	assert.Equal(t, `<p>hi</p>`, got.HTML)
+	assert.Equal(t, content.Annotations, got.Annotations)
+	assert.Equal(t, `<p onclick="x()">hi</p>`, content.HTML)
+
+	assert.Nil(t, RichTextPolicy().SanitizeContent(nil))
+}
@@ -0,0 +1,434 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types published by [EventBroker]. The data of each event is the
+// JSON message to forward to the iframe with postMessage.
+const (
+	// EventRenderData delivers new render data to the iframe.
+	EventRenderData = "ui-lifecycle-iframe-render-data"
+	// EventResourceUpdated reports that the rendered resource changed.
+	EventResourceUpdated = "resource-updated"
+	// EventMessageResponse delivers a ui-message-response produced after
+	// the original action returned.
+	EventMessageResponse = ResponseTypeResponse
+)
+
+// Request parameters read by [EventBroker].
+const (
+	// HeaderInstanceID carries the ID of the rendered resource instance.
+	HeaderInstanceID = "X-MCP-UI-Instance-ID"
+	// QueryInstanceID is the query parameter alternative to HeaderInstanceID,
+	// for EventSource clients, which cannot set headers.
+	QueryInstanceID = "instance"
+)
+
+// Defaults for [EventBroker].
+const (
+	// DefaultEventBufferSize is the number of events kept per instance for
+	// replay after a reconnect.
+	DefaultEventBufferSize = 64
+	// DefaultHeartbeatInterval is the interval between heartbeat comments.
+	DefaultHeartbeatInterval = 15 * time.Second
+)
+
+// clientQueueSize is the number of events queued per connection before a
+// slow client is disconnected. It reconnects and catches up from the
+// replay buffer.
+const clientQueueSize = 16
+
+// Event is a server-sent event published to a rendered resource instance.
+type Event struct {
+	// ID is assigned by the broker and increases per instance.
+	ID uint64
+	// Type is the SSE event name, such as [EventRenderData].
+	Type string
+	// Data is the JSON-encoded event data.
+	Data json.RawMessage
+}
+
+// RenderDataMessage is the data of an [EventRenderData] event.
+type RenderDataMessage struct {
+	// Type is always EventRenderData.
+	Type string `json:"type"`
+	// Payload holds the render data.
+	Payload RenderDataPayload `json:"payload"`
+}
+
+// RenderDataPayload is the payload of a [RenderDataMessage].
+type RenderDataPayload struct {
+	// RenderData is the data the UI renders.
+	RenderData any `json:"renderData"`
+}
+
+// EventBroker pushes updates to rendered UI resources over Server-Sent
+// Events. Each rendered resource instance is identified by an ID chosen by
+// the server, typically embedded in the HTML or URL it serves, and connects
+// with an EventSource to the broker's endpoint:
+//
+//	new EventSource("/mcp-ui/events?instance=" + instanceId)
+//
+// The server binds each instance to the session it renders for with
+// [EventBroker.Bind]; only connections from that session, as identified by
+// the SessionExtractor, receive its events. Go code then publishes to the
+// instance, or to every instance of a session. Events are numbered per
+// instance and the last BufferSize events are kept, so a client
+// reconnecting with Last-Event-ID receives the events it missed.
+//
+// It is safe for concurrent use.
+//
+// Example:
+//
+//	broker := mcpui.NewEventBroker()
+//	broker.SessionExtractor = mcpui.SessionFromCookie("session")
+//	http.Handle("/mcp-ui/events", broker)
+//
+//	// When rendering the resource:
+//	broker.Bind(instanceID, sessionID)
+//
+//	// Later, from any goroutine:
+//	broker.PublishRenderData(instanceID, map[string]any{"progress": 80})
+type EventBroker struct {
+	// BufferSize is the number of events kept per instance for replay.
+	// Zero means DefaultEventBufferSize.
+	BufferSize int
+	// HeartbeatInterval is the interval between heartbeat comments that
+	// keep idle connections open. Zero means DefaultHeartbeatInterval.
+	HeartbeatInterval time.Duration
+	// SessionExtractor identifies the session of each connection. A
+	// connection to an instance bound with [EventBroker.Bind] is rejected
+	// with 403 Forbidden unless it comes from the bound session.
+	SessionExtractor SessionExtractor
+	// SingleTenant allows connections to instances that are not bound to
+	// a session. Without it, such connections are rejected with 403
+	// Forbidden, so that clients cannot read the events of an instance by
+	// guessing its ID. Only set it when every client may see every
+	// instance, such as in a local single-user server. Instances that
+	// clients connected to but that were never published to are forgotten
+	// when their last connection closes.
+	SingleTenant bool
+
+	mu        sync.Mutex
+	instances map[string]*eventInstance
+}
+
+type eventInstance struct {
+	session string // set by Bind
+	lastID  uint64
+	buffer  []*Event
+	clients map[chan *Event]struct{}
+}
+
+// NewEventBroker creates a broker with default settings.
+func NewEventBroker() *EventBroker {
+	return &EventBroker{instances: make(map[string]*eventInstance)}
+}
+
+// instance returns the instance with the given ID, creating it if needed.
+// It is only used on behalf of the server; connections never create
+// lasting instances. b.mu must be held.
+func (b *EventBroker) instance(id string) *eventInstance {
+	inst, ok := b.instances[id]
+	if !ok {
+		inst = &eventInstance{clients: make(map[chan *Event]struct{})}
+		b.instances[id] = inst
+	}
+	return inst
+}
+
+// Bind assigns an instance to a session, so that connections from that
+// session receive its events and [EventBroker.PublishSession] reaches it.
+// Connections from other sessions are rejected. It fails if the instance
+// already belongs to another session.
+func (b *EventBroker) Bind(instanceID, sessionID string) error {
+	if instanceID == "" || sessionID == "" {
+		return errors.New("instance and session IDs are required")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	inst := b.instance(instanceID)
+	if inst.session != "" && inst.session != sessionID {
+		return fmt.Errorf("instance %q belongs to another session", instanceID)
+	}
+	inst.session = sessionID
+	return nil
+}
+
+// Publish sends an event with JSON-encoded data to every connection of an
+// instance and records it for replay. It returns the event's ID.
+func (b *EventBroker) Publish(instanceID, eventType string, data any) (uint64, error) {
+	if instanceID == "" {
+		return 0, errors.New("instance ID is required")
+	}
+	raw, err := encodeEvent(eventType, data)
+	if err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.publish(b.instance(instanceID), eventType, raw), nil
+}
+
+func encodeEvent(eventType string, data any) (json.RawMessage, error) {
+	if eventType == "" || strings.ContainsAny(eventType, "\r\n") {
+		return nil, fmt.Errorf("invalid event type %q", eventType)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s event: %w", eventType, err)
+	}
+	return raw, nil
+}
+
+// publish records and delivers an event. b.mu must be held.
+func (b *EventBroker) publish(inst *eventInstance, eventType string, data json.RawMessage) uint64 {
+	inst.lastID++
+	e := &Event{ID: inst.lastID, Type: eventType, Data: data}
+	size := b.BufferSize
+	if size <= 0 {
+		size = DefaultEventBufferSize
+	}
+	inst.buffer = append(inst.buffer, e)
+	if len(inst.buffer) > size {
+		inst.buffer = append(inst.buffer[:0:0], inst.buffer[len(inst.buffer)-size:]...)
+	}
+	for ch := range inst.clients {
+		select {
+		case ch <- e:
+		default:
+			// The client is too slow; drop it and let it replay on reconnect.
+			delete(inst.clients, ch)
+			close(ch)
+		}
+	}
+	return e.ID
+}
+
+// PublishRenderData sends new render data to an instance.
+func (b *EventBroker) PublishRenderData(instanceID string, renderData any) (uint64, error) {
+	return b.Publish(instanceID, EventRenderData, &RenderDataMessage{
+		Type:    EventRenderData,
+		Payload: RenderDataPayload{RenderData: renderData},
+	})
+}
+
+// PublishResourceUpdated tells an instance that the resource at uri changed.
+// The data is a notifications/resources/updated [Notification].
+func (b *EventBroker) PublishResourceUpdated(instanceID, uri string) (uint64, error) {
+	return b.Publish(instanceID, EventResourceUpdated, NewResourceUpdatedNotification(uri))
+}
+
+// PublishResponse delivers a response to an action that was acknowledged
+// earlier, for example with [NewReceivedResponse], and completed later.
+func (b *EventBroker) PublishResponse(instanceID string, resp *UIResponse) (uint64, error) {
+	if resp == nil {
+		return 0, errors.New("response is required")
+	}
+	return b.Publish(instanceID, EventMessageResponse, resp)
+}
+
+// PublishSession sends an event to every instance bound to a session with
+// [EventBroker.Bind] and returns the number of instances reached.
+func (b *EventBroker) PublishSession(sessionID, eventType string, data any) (int, error) {
+	if sessionID == "" {
+		return 0, errors.New("session ID is required")
+	}
+	raw, err := encodeEvent(eventType, data)
+	if err != nil {
+		return 0, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for _, id := range sortedKeys(b.instances) {
+		if inst := b.instances[id]; inst.session == sessionID {
+			b.publish(inst, eventType, raw)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Connections returns the number of open connections of an instance.
+func (b *EventBroker) Connections(instanceID string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if inst, ok := b.instances[instanceID]; ok {
+		return len(inst.clients)
+	}
+	return 0
+}
+
+// RemoveInstance closes the connections of an instance and discards its
+// replay buffer. Call it when the rendered resource goes away.
+func (b *EventBroker) RemoveInstance(instanceID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	inst, ok := b.instances[instanceID]
+	if !ok {
+		return
+	}
+	for ch := range inst.clients {
+		close(ch)
+	}
+	delete(b.instances, instanceID)
+}
+
+// RemoveSession removes every instance bound to a session.
+func (b *EventBroker) RemoveSession(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, inst := range b.instances {
+		if inst.session != sessionID {
+			continue
+		}
+		for ch := range inst.clients {
+			close(ch)
+		}
+		delete(b.instances, id)
+	}
+}
+
+// subscribe registers a connection and returns the events after lastID.
+func (b *EventBroker) subscribe(instanceID, session string, lastID uint64) (chan *Event, []*Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	inst, ok := b.instances[instanceID]
+	if !ok {
+		inst = &eventInstance{clients: make(map[chan *Event]struct{})}
+	}
+	switch {
+	case inst.session == "" && !b.SingleTenant:
+		return nil, nil, fmt.Errorf("instance %q is not bound to a session", instanceID)
+	case inst.session != "" && inst.session != session:
+		return nil, nil, fmt.Errorf("instance %q belongs to another session", instanceID)
+	}
+	var replay []*Event
+	for _, e := range inst.buffer {
+		if e.ID > lastID {
+			replay = append(replay, e)
+		}
+	}
+	ch := make(chan *Event, clientQueueSize)
+	inst.clients[ch] = struct{}{}
+	b.instances[instanceID] = inst
+	return ch, replay, nil
+}
+
+// unsubscribe removes a connection, and the instance too if nothing else
+// keeps it: no connections, no events to replay and no Bind.
+func (b *EventBroker) unsubscribe(instanceID string, ch chan *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	inst, ok := b.instances[instanceID]
+	if !ok {
+		return
+	}
+	if _, ok := inst.clients[ch]; ok {
+		delete(inst.clients, ch)
+		close(ch)
+	}
+	if len(inst.clients) == 0 && len(inst.buffer) == 0 && inst.session == "" {
+		delete(b.instances, instanceID)
+	}
+}
+
+// ServeHTTP streams the events of the instance named by the
+// X-MCP-UI-Instance-ID header or the instance query parameter.
+func (b *EventBroker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeHTTPError(w, http.StatusMethodNotAllowed, ErrorCodeInvalidRequest, "method must be GET")
+		return
+	}
+	instanceID := r.Header.Get(HeaderInstanceID)
+	if instanceID == "" {
+		instanceID = r.URL.Query().Get(QueryInstanceID)
+	}
+	if instanceID == "" {
+		writeHTTPError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, "instance ID is required")
+		return
+	}
+	var session string
+	if b.SessionExtractor != nil {
+		s, err := b.SessionExtractor(r)
+		if err != nil {
+			writeHTTPError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, err.Error())
+			return
+		}
+		session = fmt.Sprint(s)
+	}
+	var lastID uint64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, "invalid Last-Event-ID")
+			return
+		}
+		lastID = id
+	}
+	rc := http.NewResponseController(w)
+
+	ch, replay, err := b.subscribe(instanceID, session, lastID)
+	if err != nil {
+		writeHTTPError(w, http.StatusForbidden, ErrorCodeUnauthorized, err.Error())
+		return
+	}
+	defer b.unsubscribe(instanceID, ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	for _, e := range replay {
+		writeEvent(w, e)
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	interval := b.HeartbeatInterval
+	if interval <= 0 {
+		interval = DefaultHeartbeatInterval
+	}
+	heartbeat := time.NewTicker(interval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeEvent(w, e)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes e in text/event-stream format. JSON-encoded data never
+// contains raw newlines, so it fits on one data line.
+func writeEvent(w http.ResponseWriter, e *Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseStream reads events from a live SSE response.
+type sseStream struct {
+	t      *testing.T
+	resp   *http.Response
+	reader *bufio.Reader
+}
+
+func openSSE(t *testing.T, url string, header http.Header) *sseStream {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header.Set(k, v[0])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return &sseStream{t: t, resp: resp, reader: bufio.NewReader(resp.Body)}
+}
+
+// next returns the fields of the next event or comment block.
+func (s *sseStream) next() map[string]string {
+	s.t.Helper()
+	fields := make(map[string]string)
+	for {
+		line, err := s.reader.ReadString('\n')
+		require.NoError(s.t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return fields
+		}
+		if comment, ok := strings.CutPrefix(line, ": "); ok {
+			fields["comment"] = comment
+			continue
+		}
+		name, value, _ := strings.Cut(line, ": ")
+		fields[name] = value
+	}
+}
+
+func waitConnections(t *testing.T, b *EventBroker, instanceID string, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return b.Connections(instanceID) == n }, time.Second, time.Millisecond)
+}
+
+func TestEventBroker_Publish(t *testing.T) {
+	b := NewEventBroker()
+	b.SingleTenant = true
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+
+	s := openSSE(t, srv.URL+"?instance=i1", nil)
+	require.Equal(t, http.StatusOK, s.resp.StatusCode)
+	assert.Equal(t, "text/event-stream", s.resp.Header.Get("Content-Type"))
+	waitConnections(t, b, "i1", 1)
+
+	id, err := b.PublishRenderData("i1", map[string]any{"count": 3})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), id)
+	e := s.next()
+	assert.Equal(t, "1", e["id"])
+	assert.Equal(t, EventRenderData, e["event"])
+	assert.JSONEq(t, `{"type":"ui-lifecycle-iframe-render-data","payload":{"renderData":{"count":3}}}`, e["data"])
+
+	_, err = b.PublishResourceUpdated("i1", "ui://dash")
+	require.NoError(t, err)
+	e = s.next()
+	assert.Equal(t, EventResourceUpdated, e["event"])
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":"ui://dash"}}`, e["data"])
+
+	_, err = b.PublishResponse("i1", NewSuccessResponse("m1", "done"))
+	require.NoError(t, err)
+	e = s.next()
+	assert.Equal(t, "3", e["id"])
+	var resp UIResponse
+	require.NoError(t, json.Unmarshal([]byte(e["data"]), &resp))
+	assert.Equal(t, "m1", resp.MessageID)
+	assert.Equal(t, "done", resp.GetResponse())
+
+	// Other instances are unaffected.
+	_, err = b.PublishRenderData("i2", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, b.Connections("i2"))
+
+	b.RemoveInstance("i1")
+	waitConnections(t, b, "i1", 0)
+}
+
+func TestEventBroker_Replay(t *testing.T) {
+	b := NewEventBroker()
+	b.SingleTenant = true
+	b.BufferSize = 3
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+
+	for i := range 5 {
+		_, err := b.Publish("i1", "tick", i)
+		require.NoError(t, err)
+	}
+
+	s := openSSE(t, srv.URL+"?instance=i1", http.Header{"Last-Event-ID": {"3"}})
+	assert.Equal(t, map[string]string{"id": "4", "event": "tick", "data": "3"}, s.next())
+	assert.Equal(t, map[string]string{"id": "5", "event": "tick", "data": "4"}, s.next())
+
+	// Without Last-Event-ID the whole buffer is replayed.
+	s = openSSE(t, srv.URL, http.Header{HeaderInstanceID: {"i1"}})
+	assert.Equal(t, "3", s.next()["id"], "events older than the buffer are dropped")
+}
+
+func TestEventBroker_Heartbeat(t *testing.T) {
+	b := NewEventBroker()
+	b.SingleTenant = true
+	b.HeartbeatInterval = 10 * time.Millisecond
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+
+	s := openSSE(t, srv.URL+"?instance=i1", nil)
+	assert.Equal(t, map[string]string{"comment": "heartbeat"}, s.next())
+}
+
+func TestEventBroker_Sessions(t *testing.T) {
+	b := NewEventBroker()
+	b.SessionExtractor = SessionFromHeader("X-Session")
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+
+	s := openSSE(t, srv.URL+"?instance=a1", http.Header{"X-Session": {"alice"}})
+	assert.Equal(t, http.StatusForbidden, s.resp.StatusCode, "unbound instances are not served")
+	_, err := b.Publish("a1", "notice", "secret")
+	require.NoError(t, err)
+	s = openSSE(t, srv.URL+"?instance=a1", http.Header{"X-Session": {"mallory"}})
+	assert.Equal(t, http.StatusForbidden, s.resp.StatusCode, "connecting first does not claim an instance")
+
+	require.NoError(t, b.Bind("a1", "alice"))
+	require.NoError(t, b.Bind("a2", "alice"))
+	require.NoError(t, b.Bind("b1", "bob"))
+	a1 := openSSE(t, srv.URL+"?instance=a1", http.Header{"X-Session": {"alice"}})
+	assert.Equal(t, `"secret"`, a1.next()["data"], "the bound session gets the replay")
+	a2 := openSSE(t, srv.URL+"?instance=a2", http.Header{"X-Session": {"alice"}})
+	openSSE(t, srv.URL+"?instance=b1", http.Header{"X-Session": {"bob"}})
+	waitConnections(t, b, "a1", 1)
+	waitConnections(t, b, "a2", 1)
+	waitConnections(t, b, "b1", 1)
+
+	_, err = b.Publish("x1", "notice", "unbound")
+	require.NoError(t, err)
+	n, err := b.PublishSession("alice", "notice", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, `"hello"`, a1.next()["data"])
+	assert.Equal(t, `"hello"`, a2.next()["data"])
+
+	_, err = b.PublishSession("", "notice", "x")
+	assert.Error(t, err)
+
+	s = openSSE(t, srv.URL+"?instance=a1", http.Header{"X-Session": {"bob"}})
+	assert.Equal(t, http.StatusForbidden, s.resp.StatusCode)
+	assert.Error(t, b.Bind("a1", "bob"))
+	assert.NoError(t, b.Bind("c1", "bob"))
+	assert.Error(t, b.Bind("c2", ""))
+
+	s = openSSE(t, srv.URL+"?instance=a1", nil)
+	assert.Equal(t, http.StatusUnauthorized, s.resp.StatusCode)
+
+	b.RemoveSession("alice")
+	waitConnections(t, b, "a1", 0)
+	waitConnections(t, b, "a2", 0)
+	assert.Equal(t, 1, b.Connections("b1"))
+}
+
+func TestEventBroker_ForgetsIdleInstances(t *testing.T) {
+	b := NewEventBroker()
+	b.SingleTenant = true
+	b.SessionExtractor = SessionFromHeader("X-Session")
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+
+	require.NoError(t, b.Bind("bound", "alice"))
+	_, err := b.Publish("published", "tick", 1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, id := range []string{"x1", "x2", "x3", "bound", "published"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?instance="+id, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Session", "alice")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		waitConnections(t, b, id, 1)
+	}
+	b.mu.Lock()
+	assert.Len(t, b.instances, 5)
+	b.mu.Unlock()
+
+	cancel()
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return len(b.instances) == 2
+	}, time.Second, time.Millisecond, "instances without events or a binding are removed")
+	b.mu.Lock()
+	assert.Contains(t, b.instances, "bound")
+	assert.Contains(t, b.instances, "published")
+	b.mu.Unlock()
+}
+
+func TestEventBroker_Errors(t *testing.T) {
+	b := NewEventBroker()
+
+	_, err := b.Publish("", "tick", nil)
+	assert.Error(t, err)
+	_, err = b.Publish("i1", "bad\nevent", nil)
+	assert.Error(t, err)
+	_, err = b.Publish("i1", "tick", func() {})
+	assert.Error(t, err)
+	_, err = b.PublishResponse("i1", nil)
+	assert.Error(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		header http.Header
+		status int
+	}{
+		{"wrong method", http.MethodPost, "/?instance=i1", nil, http.StatusMethodNotAllowed},
+		{"missing instance", http.MethodGet, "/", nil, http.StatusBadRequest},
+		{"bad Last-Event-ID", http.MethodGet, "/?instance=i1", http.Header{"Last-Event-Id": {"x"}}, http.StatusBadRequest},
+		{"unbound instance", http.MethodGet, "/?instance=i1", nil, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v[0])
+			}
+			rec := httptest.NewRecorder()
+			b.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+	assert.Empty(t, b.instances, "rejected connections leave nothing behind")
+}
+
+func TestEventBroker_SlowClient(t *testing.T) {
+	b := NewEventBroker()
+	b.SingleTenant = true
+	ch, _, err := b.subscribe("i1", "", 0)
+	require.NoError(t, err)
+
+	for i := range clientQueueSize + 1 {
+		_, err := b.Publish("i1", "tick", i)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, b.Connections("i1"), "slow client is dropped")
+	n := 0
+	for range ch {
+		n++
+	}
+	assert.Equal(t, clientQueueSize, n, "queued events are still delivered")
+}
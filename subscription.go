@@ -0,0 +1,293 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MCP notification methods for resources.
+const (
+	// MethodResourceUpdated notifies a subscribed client that a resource
+	// changed and should be read again.
+	MethodResourceUpdated = "notifications/resources/updated"
+	// MethodResourceListChanged notifies clients that the list of available
+	// resources changed.
+	MethodResourceListChanged = "notifications/resources/list_changed"
+)
+
+// JSONRPCVersion is the JSON-RPC protocol version used by MCP.
+const JSONRPCVersion = "2.0"
+
+// Notification is a JSON-RPC 2.0 notification message.
+type Notification struct {
+	// JSONRPC is always "2.0".
+	JSONRPC string `json:"jsonrpc"`
+	// Method is the notification method.
+	Method string `json:"method"`
+	// Params holds the notification parameters, if any.
+	Params any `json:"params,omitempty"`
+}
+
+// ResourceUpdatedParams are the parameters of a resource-updated notification.
+type ResourceUpdatedParams struct {
+	// URI is the URI of the updated resource.
+	URI string `json:"uri"`
+}
+
+// NewResourceUpdatedNotification creates a notifications/resources/updated
+// message for uri.
+func NewResourceUpdatedNotification(uri string) *Notification {
+	return &Notification{
+		JSONRPC: JSONRPCVersion,
+		Method:  MethodResourceUpdated,
+		Params:  &ResourceUpdatedParams{URI: uri},
+	}
+}
+
+// NewResourceListChangedNotification creates a
+// notifications/resources/list_changed message.
+func NewResourceListChangedNotification() *Notification {
+	return &Notification{JSONRPC: JSONRPCVersion, Method: MethodResourceListChanged}
+}
+
+// Notifier delivers notifications to client sessions. Implementations wrap
+// the server's transport, for example an MCP server session or an SSE stream.
+type Notifier interface {
+	// Notify sends a notification to one session.
+	Notify(ctx context.Context, sessionID string, n *Notification) error
+	// Broadcast sends a notification to every connected session.
+	Broadcast(ctx context.Context, n *Notification) error
+}
+
+// SubscriptionManager tracks resources/subscribe requests and fans out
+// resource-updated notifications to the subscribed sessions through a
+// [Notifier]. Without a notifier, notifications are dropped. It is safe for
+// concurrent use.
+//
+// [ResourceRegistry] embeds a manager and only accepts subscriptions to the
+// resources it serves; use a standalone manager with other resource
+// sources.
+//
+// Example:
+//
+//	subs := mcpui.NewSubscriptionManager(notifier)
+//
+//	// In the resources/subscribe handler:
+//	subs.Subscribe(params.URI, session.ID())
+//
+//	// When a resource changes:
+//	subs.NotifyUpdated(ctx, "ui://dashboard/main")
+type SubscriptionManager struct {
+	mu        sync.RWMutex
+	notifier  Notifier
+	byURI     map[string]map[string]struct{} // uri -> session IDs
+	bySession map[string]map[string]struct{} // session ID -> uris
+}
+
+// NewSubscriptionManager creates a manager delivering notifications through
+// notifier, which may be nil.
+func NewSubscriptionManager(notifier Notifier) *SubscriptionManager {
+	return &SubscriptionManager{
+		notifier:  notifier,
+		byURI:     make(map[string]map[string]struct{}),
+		bySession: make(map[string]map[string]struct{}),
+	}
+}
+
+// SetNotifier replaces the notifier. A nil notifier drops notifications.
+func (m *SubscriptionManager) SetNotifier(notifier Notifier) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = notifier
+}
+
+// Subscribe subscribes a session to updates of the resource at uri.
+// Subscribing twice has no further effect.
+func (m *SubscriptionManager) Subscribe(uri, sessionID string) error {
+	if uri == "" {
+		return errors.New("URI is required")
+	}
+	if sessionID == "" {
+		return errors.New("session ID is required")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	addToSet(m.byURI, uri, sessionID)
+	addToSet(m.bySession, sessionID, uri)
+	return nil
+}
+
+// Unsubscribe removes a session's subscription to uri and reports whether
+// it existed.
+func (m *SubscriptionManager) Unsubscribe(uri, sessionID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !removeFromSet(m.byURI, uri, sessionID) {
+		return false
+	}
+	removeFromSet(m.bySession, sessionID, uri)
+	return true
+}
+
+// RemoveSession drops all subscriptions of a session. Call it when the
+// session disconnects.
+func (m *SubscriptionManager) RemoveSession(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for uri := range m.bySession[sessionID] {
+		removeFromSet(m.byURI, uri, sessionID)
+	}
+	delete(m.bySession, sessionID)
+}
+
+// Subscribers returns the sorted IDs of the sessions subscribed to uri.
+func (m *SubscriptionManager) Subscribers(uri string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return sortedKeys(m.byURI[uri])
+}
+
+// Subscriptions returns the sorted URIs a session is subscribed to.
+func (m *SubscriptionManager) Subscriptions(sessionID string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return sortedKeys(m.bySession[sessionID])
+}
+
+// NotifyUpdated sends a notifications/resources/updated message for uri to
+// every subscribed session. Delivery continues past failures; the returned
+// error joins the errors of all failed sessions.
+func (m *SubscriptionManager) NotifyUpdated(ctx context.Context, uri string) error {
+	m.mu.RLock()
+	notifier := m.notifier
+	sessions := sortedKeys(m.byURI[uri])
+	m.mu.RUnlock()
+	if notifier == nil {
+		return nil
+	}
+
+	n := NewResourceUpdatedNotification(uri)
+	var errs []error
+	for _, sessionID := range sessions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := notifier.Notify(ctx, sessionID, n); err != nil {
+			errs = append(errs, fmt.Errorf("session %s: %w", sessionID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotifyListChanged broadcasts a notifications/resources/list_changed
+// message to all sessions.
+func (m *SubscriptionManager) NotifyListChanged(ctx context.Context) error {
+	m.mu.RLock()
+	notifier := m.notifier
+	m.mu.RUnlock()
+	if notifier == nil {
+		return nil
+	}
+	return notifier.Broadcast(ctx, NewResourceListChangedNotification())
+}
+
+// HandleResourceEvent forwards a [ResourceWatcher] event: updates notify the
+// resource's subscribers, while creations and deletions change the resource
+// list. A deleted resource also notifies its subscribers so that clients stop
+// showing stale content.
+//
+//	watcher.AddListener(func(e mcpui.ResourceEvent) {
+//		if err := subs.HandleResourceEvent(ctx, e); err != nil {
+//			log.Print(err)
+//		}
+//	})
+func (m *SubscriptionManager) HandleResourceEvent(ctx context.Context, e ResourceEvent) error {
+	switch e.Kind {
+	case ResourceUpdated:
+		return m.NotifyUpdated(ctx, e.URI)
+	case ResourceDeleted:
+		return errors.Join(m.NotifyUpdated(ctx, e.URI), m.NotifyListChanged(ctx))
+	default:
+		return m.NotifyListChanged(ctx)
+	}
+}
+
+// SetNotifier sets the notifier that delivers the registry's subscription
+// notifications. Until it is called, notifications are dropped.
+func (r *ResourceRegistry) SetNotifier(notifier Notifier) {
+	r.subs.SetNotifier(notifier)
+}
+
+// Subscribe subscribes a session to updates of the resource at uri, as
+// requested by resources/subscribe. The URI must be registered or match a
+// registered template; otherwise the error wraps [ErrResourceNotFound].
+func (r *ResourceRegistry) Subscribe(uri, sessionID string) error {
+	r.mu.RLock()
+	served := r.serves(uri)
+	r.mu.RUnlock()
+	if !served {
+		return fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+	}
+	return r.subs.Subscribe(uri, sessionID)
+}
+
+// Unsubscribe removes a session's subscription to uri, as requested by
+// resources/unsubscribe, and reports whether it existed.
+func (r *ResourceRegistry) Unsubscribe(uri, sessionID string) bool {
+	return r.subs.Unsubscribe(uri, sessionID)
+}
+
+// RemoveSession drops all subscriptions of a session. Call it when the
+// session disconnects.
+func (r *ResourceRegistry) RemoveSession(sessionID string) {
+	r.subs.RemoveSession(sessionID)
+}
+
+// Subscriptions returns the registry's subscription manager, for example to
+// forward [ResourceWatcher] events with
+// [SubscriptionManager.HandleResourceEvent].
+func (r *ResourceRegistry) Subscriptions() *SubscriptionManager {
+	return r.subs
+}
+
+// NotifyUpdated tells the sessions subscribed to uri that it changed. See
+// [SubscriptionManager.NotifyUpdated].
+func (r *ResourceRegistry) NotifyUpdated(ctx context.Context, uri string) error {
+	return r.subs.NotifyUpdated(ctx, uri)
+}
+
+// NotifyListChanged tells all sessions that the list of resources changed,
+// for example after Register or Unregister.
+func (r *ResourceRegistry) NotifyListChanged(ctx context.Context) error {
+	return r.subs.NotifyListChanged(ctx)
+}
+
+func addToSet(sets map[string]map[string]struct{}, key, member string) {
+	set, ok := sets[key]
+	if !ok {
+		set = make(map[string]struct{})
+		sets[key] = set
+	}
+	set[member] = struct{}{}
+}
+
+func removeFromSet(sets map[string]map[string]struct{}, key, member string) bool {
+	set, ok := sets[key]
+	if !ok {
+		return false
+	}
+	if _, ok := set[member]; !ok {
+		return false
+	}
+	delete(set, member)
+	if len(set) == 0 {
+		delete(sets, key)
+	}
+	return true
+}
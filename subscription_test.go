@@ -0,0 +1,160 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier records notifications and fails for selected sessions.
+type recordingNotifier struct {
+	mu        sync.Mutex
+	sent      map[string][]*Notification
+	broadcast []*Notification
+	fail      map[string]bool
+}
+
+func newRecordingNotifier() *recordingNotifier {
+	return &recordingNotifier{sent: make(map[string][]*Notification), fail: make(map[string]bool)}
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, sessionID string, msg *Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.fail[sessionID] {
+		return errors.New("connection closed")
+	}
+	n.sent[sessionID] = append(n.sent[sessionID], msg)
+	return nil
+}
+
+func (n *recordingNotifier) Broadcast(_ context.Context, msg *Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.broadcast = append(n.broadcast, msg)
+	return nil
+}
+
+func TestNotification_JSON(t *testing.T) {
+	data, err := json.Marshal(NewResourceUpdatedNotification("ui://dashboard/main"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":"ui://dashboard/main"}}`, string(data))
+
+	data, err = json.Marshal(NewResourceListChangedNotification())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/resources/list_changed"}`, string(data))
+}
+
+func TestSubscriptionManager(t *testing.T) {
+	notifier := newRecordingNotifier()
+	m := NewSubscriptionManager(notifier)
+	ctx := context.Background()
+
+	require.NoError(t, m.Subscribe("ui://a", "s1"))
+	require.NoError(t, m.Subscribe("ui://a", "s2"))
+	require.NoError(t, m.Subscribe("ui://a", "s2"))
+	require.NoError(t, m.Subscribe("ui://b", "s1"))
+	assert.Error(t, m.Subscribe("", "s1"))
+	assert.Error(t, m.Subscribe("ui://a", ""))
+
+	assert.Equal(t, []string{"s1", "s2"}, m.Subscribers("ui://a"))
+	assert.Equal(t, []string{"ui://a", "ui://b"}, m.Subscriptions("s1"))
+
+	require.NoError(t, m.NotifyUpdated(ctx, "ui://a"))
+	require.NoError(t, m.NotifyUpdated(ctx, "ui://unwatched"))
+	assert.Len(t, notifier.sent["s1"], 1)
+	assert.Len(t, notifier.sent["s2"], 1)
+	assert.Equal(t, MethodResourceUpdated, notifier.sent["s1"][0].Method)
+	assert.Equal(t, &ResourceUpdatedParams{URI: "ui://a"}, notifier.sent["s1"][0].Params)
+
+	assert.True(t, m.Unsubscribe("ui://a", "s2"))
+	assert.False(t, m.Unsubscribe("ui://a", "s2"))
+	assert.Equal(t, []string{"s1"}, m.Subscribers("ui://a"))
+
+	m.RemoveSession("s1")
+	assert.Empty(t, m.Subscribers("ui://a"))
+	assert.Empty(t, m.Subscriptions("s1"))
+}
+
+func TestSubscriptionManager_DeliveryErrors(t *testing.T) {
+	notifier := newRecordingNotifier()
+	notifier.fail["bad"] = true
+	m := NewSubscriptionManager(notifier)
+	require.NoError(t, m.Subscribe("ui://a", "bad"))
+	require.NoError(t, m.Subscribe("ui://a", "good"))
+
+	err := m.NotifyUpdated(context.Background(), "ui://a")
+	assert.ErrorContains(t, err, "session bad: connection closed")
+	assert.Len(t, notifier.sent["good"], 1, "delivery continues past failures")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, m.NotifyUpdated(ctx, "ui://a"), context.Canceled)
+}
+
+func TestSubscriptionManager_HandleResourceEvent(t *testing.T) {
+	notifier := newRecordingNotifier()
+	m := NewSubscriptionManager(notifier)
+	ctx := context.Background()
+	require.NoError(t, m.Subscribe("ui://a", "s1"))
+
+	require.NoError(t, m.HandleResourceEvent(ctx, ResourceEvent{URI: "ui://a", Kind: ResourceUpdated}))
+	assert.Len(t, notifier.sent["s1"], 1)
+	assert.Empty(t, notifier.broadcast)
+
+	require.NoError(t, m.HandleResourceEvent(ctx, ResourceEvent{URI: "ui://new", Kind: ResourceCreated}))
+	require.Len(t, notifier.broadcast, 1)
+	assert.Equal(t, MethodResourceListChanged, notifier.broadcast[0].Method)
+
+	require.NoError(t, m.HandleResourceEvent(ctx, ResourceEvent{URI: "ui://a", Kind: ResourceDeleted}))
+	assert.Len(t, notifier.sent["s1"], 2)
+	assert.Len(t, notifier.broadcast, 2)
+}
+
+func TestSubscriptionManager_NilNotifier(t *testing.T) {
+	m := NewSubscriptionManager(nil)
+	ctx := context.Background()
+	require.NoError(t, m.Subscribe("ui://a", "s1"))
+	assert.NoError(t, m.NotifyUpdated(ctx, "ui://a"), "notifications are dropped")
+	assert.NoError(t, m.NotifyListChanged(ctx))
+
+	notifier := newRecordingNotifier()
+	m.SetNotifier(notifier)
+	require.NoError(t, m.NotifyUpdated(ctx, "ui://a"))
+	assert.Len(t, notifier.sent["s1"], 1)
+}
+
+func TestResourceRegistry_Subscribe(t *testing.T) {
+	r := NewResourceRegistry()
+	ctx := context.Background()
+	require.NoError(t, r.Register(&UIResource{URI: "ui://dashboard/main", Name: "dashboard"}, &HTMLContent{HTML: "<p>d</p>"}))
+	require.NoError(t, r.RegisterTemplate(&UIResourceTemplate{URITemplate: "ui://orders/{id}", Name: "order"},
+		func(context.Context, string, map[string]string) (UIContent, error) { return &HTMLContent{}, nil }))
+
+	assert.NoError(t, r.NotifyUpdated(ctx, "ui://dashboard/main"), "no notifier yet")
+
+	notifier := newRecordingNotifier()
+	r.SetNotifier(notifier)
+	require.NoError(t, r.Subscribe("ui://dashboard/main", "s1"))
+	require.NoError(t, r.Subscribe("ui://orders/42", "s1"))
+	assert.ErrorIs(t, r.Subscribe("ui://missing", "s1"), ErrResourceNotFound)
+	assert.Equal(t, []string{"ui://dashboard/main", "ui://orders/42"}, r.Subscriptions().Subscriptions("s1"))
+
+	require.NoError(t, r.NotifyUpdated(ctx, "ui://dashboard/main"))
+	require.NoError(t, r.NotifyListChanged(ctx))
+	assert.Len(t, notifier.sent["s1"], 1)
+	assert.Len(t, notifier.broadcast, 1)
+
+	assert.True(t, r.Unsubscribe("ui://orders/42", "s1"))
+	r.RemoveSession("s1")
+	assert.Empty(t, r.Subscriptions().Subscriptions("s1"))
+}
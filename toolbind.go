@@ -0,0 +1,198 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// MetaKeyResourceTemplate is the tool _meta key linking a tool to the
+// [UIResourceTemplate] its UI resources are expanded from, for tools whose
+// result URI depends on their arguments.
+const MetaKeyResourceTemplate = "mcpui/resourceTemplate"
+
+// ToolDefinition holds the parts of an MCP tool definition that link the
+// tool to its UI. Fill it from an SDK's tool type, or decode the entries of
+// a tools/list result into it.
+type ToolDefinition struct {
+	// Name is the tool name.
+	Name string `json:"name"`
+	// Meta is the tool's _meta.
+	Meta map[string]any `json:"_meta,omitempty"`
+}
+
+// LinkToolResource links a tool to the UI resource that renders its results,
+// storing the resource URI in the tool's _meta as [SetToolUIMeta] does, and
+// returns the _meta. An existing [ToolUIMeta].Visibility is kept. A nil
+// resource leaves meta unchanged.
+//
+// Example:
+//
+//	tool.Meta = mcpui.LinkToolResource(tool.Meta, dashboard)
+func LinkToolResource(meta map[string]any, resource *UIResource) map[string]any {
+	if resource == nil {
+		return meta
+	}
+	m := &ToolUIMeta{ResourceURI: resource.URI}
+	if old, ok := ParseToolUIMeta(meta); ok {
+		m.Visibility = old.Visibility
+	}
+	return SetToolUIMeta(meta, m)
+}
+
+// LinkToolTemplate links a tool to the resource template its UI resources
+// are expanded from, storing the URI template under
+// [MetaKeyResourceTemplate], and returns the _meta. A nil template leaves
+// meta unchanged.
+//
+// Example:
+//
+//	tool.Meta = mcpui.LinkToolTemplate(tool.Meta, &mcpui.UIResourceTemplate{URITemplate: "ui://orders/{id}", Name: "order"})
+func LinkToolTemplate(meta map[string]any, template *UIResourceTemplate) map[string]any {
+	if template == nil {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[MetaKeyResourceTemplate] = template.URITemplate
+	return meta
+}
+
+// ToolBinding is a link from a tool to a UI resource or resource template.
+type ToolBinding struct {
+	// Tool is the tool name.
+	Tool string `json:"tool"`
+	// Key is the _meta key holding the link.
+	Key string `json:"key"`
+	// URI is the resource URI, or the URI template if Template is set.
+	URI string `json:"uri"`
+	// Template reports that URI is a URI template.
+	Template bool `json:"template,omitempty"`
+	// Registered reports whether the registry serves the URI. It is only
+	// set by [ResourceRegistry.CheckToolBindings].
+	Registered bool `json:"registered"`
+}
+
+// ToolBindings returns the UI links in a tool's _meta: the MCP Apps "ui"
+// entry or its legacy "ui/resourceUri" form, "openai/outputTemplate" and
+// [MetaKeyResourceTemplate], in that order. A URI linked under several keys
+// is listed once. A nil tool has no links.
+func ToolBindings(tool *ToolDefinition) []*ToolBinding {
+	if tool == nil {
+		return nil
+	}
+	var bindings []*ToolBinding
+	add := func(key, uri string, template bool) {
+		if uri == "" || slices.ContainsFunc(bindings, func(b *ToolBinding) bool {
+			return b.URI == uri && b.Template == template
+		}) {
+			return
+		}
+		bindings = append(bindings, &ToolBinding{Tool: tool.Name, Key: key, URI: uri, Template: template})
+	}
+
+	if m, key, ok := parseToolUIMeta(tool.Meta); ok {
+		add(key, m.ResourceURI, false)
+	}
+	if uri, ok := tool.Meta[MetaKeyOutputTemplate].(string); ok {
+		add(MetaKeyOutputTemplate, uri, false)
+	}
+	if uri, ok := tool.Meta[MetaKeyResourceTemplate].(string); ok {
+		add(MetaKeyResourceTemplate, uri, true)
+	}
+	return bindings
+}
+
+// ToolBindingReport lists the UI links of a set of tools. It is produced by
+// [ResourceRegistry.CheckToolBindings].
+type ToolBindingReport struct {
+	// Bindings lists the links of all tools, in tool order.
+	Bindings []*ToolBinding `json:"bindings"`
+	// Unbound lists the tools without a UI link.
+	Unbound []string `json:"unbound,omitempty"`
+}
+
+// Missing returns the bindings whose URI the registry does not serve.
+func (r *ToolBindingReport) Missing() []*ToolBinding {
+	var missing []*ToolBinding
+	for _, b := range r.Bindings {
+		if !b.Registered {
+			missing = append(missing, b)
+		}
+	}
+	return missing
+}
+
+// Err returns an error wrapping [ErrResourceNotFound] for each binding the
+// registry does not serve, or nil if all are served.
+func (r *ToolBindingReport) Err() error {
+	var errs []error
+	for _, b := range r.Missing() {
+		kind := "resource"
+		if b.Template {
+			kind = "resource template"
+		}
+		errs = append(errs, fmt.Errorf("%w: tool %q links to %s %s in _meta[%q]", ErrResourceNotFound, b.Tool, kind, b.URI, b.Key))
+	}
+	return errors.Join(errs...)
+}
+
+// CheckToolBindings reports the UI links of tools and whether the registry
+// serves them. A resource URI is served if it is registered or matches a
+// registered template; a template link must name a registered template
+// exactly. Nil tools are skipped. Call the report's Err method to fail on
+// broken links, for example at startup or in a test.
+//
+// Example:
+//
+//	report := registry.CheckToolBindings(
+//		&mcpui.ToolDefinition{Name: "show_dashboard", Meta: dashboardTool.Meta},
+//		&mcpui.ToolDefinition{Name: "show_order", Meta: orderTool.Meta},
+//	)
+//	if err := report.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+func (r *ResourceRegistry) CheckToolBindings(tools ...*ToolDefinition) *ToolBindingReport {
+	report := &ToolBindingReport{Bindings: []*ToolBinding{}}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, tool := range tools {
+		if tool == nil {
+			continue
+		}
+		bindings := ToolBindings(tool)
+		if len(bindings) == 0 {
+			report.Unbound = append(report.Unbound, tool.Name)
+			continue
+		}
+		for _, b := range bindings {
+			if b.Template {
+				b.Registered = slices.ContainsFunc(r.templates, func(e *templateEntry) bool {
+					return e.template.URITemplate == b.URI
+				})
+			} else {
+				b.Registered = r.serves(b.URI)
+			}
+		}
+		report.Bindings = append(report.Bindings, bindings...)
+	}
+	return report
+}
+
+// serves reports whether uri is registered or matches a template. The
+// caller must hold r.mu.
+func (r *ResourceRegistry) serves(uri string) bool {
+	if _, ok := r.resources[uri]; ok {
+		return true
+	}
+	return slices.ContainsFunc(r.templates, func(e *templateEntry) bool {
+		_, ok := e.parsed.Match(uri)
+		return ok
+	})
+}
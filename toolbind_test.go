@@ -0,0 +1,125 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkToolResource(t *testing.T) {
+	meta := LinkToolResource(nil, &UIResource{URI: "ui://dashboard"})
+	assert.Equal(t, map[string]any{
+		"ui":             &ToolUIMeta{ResourceURI: "ui://dashboard"},
+		"ui/resourceUri": "ui://dashboard",
+	}, meta)
+
+	// Relinking keeps the visibility and other entries.
+	meta = SetToolUIMeta(map[string]any{"other": 1}, &ToolUIMeta{ResourceURI: "ui://old", Visibility: []string{VisibilityApp}})
+	meta = LinkToolResource(meta, &UIResource{URI: "ui://new"})
+	assert.Equal(t, map[string]any{
+		"other":          1,
+		"ui":             &ToolUIMeta{ResourceURI: "ui://new", Visibility: []string{VisibilityApp}},
+		"ui/resourceUri": "ui://new",
+	}, meta)
+
+	assert.Equal(t, map[string]any{"other": 1}, LinkToolResource(map[string]any{"other": 1}, nil), "nil resources are ignored")
+	assert.Nil(t, LinkToolResource(nil, nil))
+}
+
+func TestLinkToolTemplate(t *testing.T) {
+	meta := LinkToolTemplate(nil, &UIResourceTemplate{URITemplate: "ui://orders/{id}"})
+	assert.Equal(t, map[string]any{"mcpui/resourceTemplate": "ui://orders/{id}"}, meta)
+
+	assert.Equal(t, map[string]any{"other": 1}, LinkToolTemplate(map[string]any{"other": 1}, nil), "nil templates are ignored")
+	assert.Nil(t, LinkToolTemplate(nil, nil))
+}
+
+func TestToolBindings(t *testing.T) {
+	tests := []struct {
+		name string
+		meta string
+		want []*ToolBinding
+	}{
+		{"none", `{}`, nil},
+		{"ui", `{"ui": {"resourceUri": "ui://a"}, "ui/resourceUri": "ui://a"}`, []*ToolBinding{
+			{Tool: "t", Key: "ui", URI: "ui://a"},
+		}},
+		{"legacy", `{"ui/resourceUri": "ui://a"}`, []*ToolBinding{
+			{Tool: "t", Key: "ui/resourceUri", URI: "ui://a"},
+		}},
+		{"ui without resourceUri", `{"ui": {"visibility": ["app"]}, "ui/resourceUri": "ui://a"}`, []*ToolBinding{
+			{Tool: "t", Key: "ui/resourceUri", URI: "ui://a"},
+		}},
+		{"all hosts", `{
+			"ui": {"resourceUri": "ui://a"},
+			"openai/outputTemplate": "ui://a-widget",
+			"mcpui/resourceTemplate": "ui://orders/{id}"
+		}`, []*ToolBinding{
+			{Tool: "t", Key: "ui", URI: "ui://a"},
+			{Tool: "t", Key: "openai/outputTemplate", URI: "ui://a-widget"},
+			{Tool: "t", Key: "mcpui/resourceTemplate", URI: "ui://orders/{id}", Template: true},
+		}},
+		{"same URI", `{"ui": {"resourceUri": "ui://a"}, "openai/outputTemplate": "ui://a"}`, []*ToolBinding{
+			{Tool: "t", Key: "ui", URI: "ui://a"},
+		}},
+		{"wrong types", `{"openai/outputTemplate": 1, "mcpui/resourceTemplate": ""}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &ToolDefinition{Name: "t"}
+			require.NoError(t, json.Unmarshal([]byte(tt.meta), &tool.Meta))
+			assert.Equal(t, tt.want, ToolBindings(tool))
+		})
+	}
+
+	assert.Nil(t, ToolBindings(nil))
+}
+
+func TestResourceRegistry_CheckToolBindings(t *testing.T) {
+	r := NewResourceRegistry()
+	require.NoError(t, r.Register(&UIResource{URI: "ui://dashboard", Name: "dashboard"}, &HTMLContent{HTML: "<p>d</p>"}))
+	require.NoError(t, r.RegisterTemplate(&UIResourceTemplate{URITemplate: "ui://orders/{id}", Name: "order"},
+		func(ctx context.Context, uri string, vars map[string]string) (UIContent, error) {
+			return &HTMLContent{HTML: vars["id"]}, nil
+		}))
+
+	report := r.CheckToolBindings(
+		&ToolDefinition{Name: "show_dashboard", Meta: LinkToolResource(nil, &UIResource{URI: "ui://dashboard"})},
+		&ToolDefinition{Name: "show_order", Meta: LinkToolTemplate(nil, &UIResourceTemplate{URITemplate: "ui://orders/{id}"})},
+		&ToolDefinition{Name: "show_latest_order", Meta: SetOpenAIToolMeta(nil, "ui://orders/latest")},
+		&ToolDefinition{Name: "search"},
+		nil,
+	)
+	assert.Equal(t, &ToolBindingReport{
+		Bindings: []*ToolBinding{
+			{Tool: "show_dashboard", Key: "ui", URI: "ui://dashboard", Registered: true},
+			{Tool: "show_order", Key: "mcpui/resourceTemplate", URI: "ui://orders/{id}", Template: true, Registered: true},
+			{Tool: "show_latest_order", Key: "openai/outputTemplate", URI: "ui://orders/latest", Registered: true},
+		},
+		Unbound: []string{"search"},
+	}, report)
+	assert.Empty(t, report.Missing())
+	assert.NoError(t, report.Err())
+
+	report = r.CheckToolBindings(
+		&ToolDefinition{Name: "old", Meta: LinkToolResource(nil, &UIResource{URI: "ui://removed"})},
+		&ToolDefinition{Name: "typo", Meta: LinkToolTemplate(nil, &UIResourceTemplate{URITemplate: "ui://order/{id}"})},
+	)
+	require.Len(t, report.Missing(), 2)
+	err := report.Err()
+	assert.True(t, errors.Is(err, ErrResourceNotFound))
+	assert.EqualError(t, err, `resource not found: tool "old" links to resource ui://removed in _meta["ui"]`+"\n"+
+		`resource not found: tool "typo" links to resource template ui://order/{id} in _meta["mcpui/resourceTemplate"]`)
+
+	empty := r.CheckToolBindings()
+	assert.Equal(t, []*ToolBinding{}, empty.Bindings)
+	assert.NoError(t, empty.Err())
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Tool result content types.
+const (
+	// ToolContentText is plain text content.
+	ToolContentText = "text"
+	// ToolContentResource is an embedded resource.
+	ToolContentResource = "resource"
+)
+
+// ToolResult is the result of an MCP tools/call request. It marshals to
+// the CallToolResult wire format, so it can be returned from a raw
+// JSON-RPC server or converted to any MCP SDK's result type. Build it with
+// [NewToolResult].
+type ToolResult struct {
+	// Content holds the unstructured result: UI resources and text.
+	Content []*ToolContent `json:"content"`
+	// StructuredContent holds an optional JSON object result.
+	StructuredContent any `json:"structuredContent,omitempty"`
+	// IsError reports that the tool failed. The error is described in
+	// Content so the model can see it.
+	IsError bool `json:"isError,omitempty"`
+	// Meta holds optional metadata, sent as _meta.
+	Meta map[string]any `json:"_meta,omitempty"`
+}
+
+// ToolContent is one entry of [ToolResult].Content: text or an embedded
+// resource.
+type ToolContent struct {
+	// Type is ToolContentText or ToolContentResource.
+	Type string `json:"type"`
+	// Text is the text of a text entry.
+	Text string `json:"text,omitempty"`
+	// Resource is the embedded resource of a resource entry.
+	Resource *UIResourceContents `json:"resource,omitempty"`
+	// Annotations contains optional metadata.
+	Annotations *Annotations `json:"annotations,omitempty"`
+}
+
+// UIResources returns the embedded resources of the result in order.
+func (r *ToolResult) UIResources() []*UIResourceContents {
+	var resources []*UIResourceContents
+	for _, c := range r.Content {
+		if c.Type == ToolContentResource && c.Resource != nil {
+			resources = append(resources, c.Resource)
+		}
+	}
+	return resources
+}
+
+// ToolResultBuilder builds a [ToolResult]. Methods can be chained; the
+// first error is reported by [ToolResultBuilder.Build].
+//
+// Example:
+//
+//	result, err := mcpui.NewToolResult().
+//		UI("ui://dashboard/main", &mcpui.HTMLContent{HTML: html}).
+//		Text("Dashboard: 3 services running, 1 degraded.").
+//		Structured(map[string]any{"running": 3, "degraded": 1}).
+//		Build()
+type ToolResultBuilder struct {
+	result   ToolResult
+	fallback bool
+	err      error
+}
+
+// NewToolResult starts an empty tool result.
+func NewToolResult() *ToolResultBuilder {
+	return &ToolResultBuilder{result: ToolResult{Content: []*ToolContent{}}}
+}
+
+// UI adds an embedded UI resource with the given URI and content.
+func (b *ToolResultBuilder) UI(uri string, content UIContent) *ToolResultBuilder {
+	rc, err := NewUIResourceContents(uri, content)
+	if err != nil {
+		b.fail(fmt.Errorf("UI resource %q: %w", uri, err))
+		return b
+	}
+	return b.Resource(rc)
+}
+
+// Resource adds embedded resource contents, such as those returned by
+// [ResourceRegistry.Read].
+func (b *ToolResultBuilder) Resource(rc *UIResourceContents) *ToolResultBuilder {
+	if rc == nil || rc.URI == "" {
+		b.fail(errors.New("resource contents require a URI"))
+		return b
+	}
+	b.result.Content = append(b.result.Content, &ToolContent{Type: ToolContentResource, Resource: rc})
+	return b
+}
+
+// Text adds a text entry, typically a fallback for clients that do not
+// render UI resources.
+func (b *ToolResultBuilder) Text(text string) *ToolResultBuilder {
+	b.result.Content = append(b.result.Content, &ToolContent{Type: ToolContentText, Text: text})
+	return b
+}
+
+// Structured sets the structured result. MCP requires it to be a JSON
+// object.
+func (b *ToolResultBuilder) Structured(v any) *ToolResultBuilder {
+	b.result.StructuredContent = v
+	return b
+}
+
+// Error marks the result as a tool error and adds err's message as text.
+func (b *ToolResultBuilder) Error(err error) *ToolResultBuilder {
+	b.result.IsError = true
+	if err != nil {
+		b.Text(err.Error())
+	}
+	return b
+}
+
+// Meta sets a _meta entry.
+func (b *ToolResultBuilder) Meta(key string, value any) *ToolResultBuilder {
+	if b.result.Meta == nil {
+		b.result.Meta = make(map[string]any)
+	}
+	b.result.Meta[key] = value
+	return b
+}
+
+// WithTextFallback makes Build add a [TextFallback] text entry for each UI
+// resource when the result has no text of its own, so clients that do not
+// render UI still see the content.
+func (b *ToolResultBuilder) WithTextFallback() *ToolResultBuilder {
+	b.fallback = true
+	return b
+}
+
+// Build returns the result, or the first error of the previous calls.
+func (b *ToolResultBuilder) Build() (*ToolResult, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	result := b.result
+	result.Content = slices.Clone(b.result.Content)
+	result.Meta = maps.Clone(b.result.Meta)
+	if b.fallback && !slices.ContainsFunc(result.Content, isTextContent) {
+		for _, rc := range result.UIResources() {
+			content, err := rc.ToUIContent()
+			if err != nil {
+				continue // not a UI content type; nothing to describe
+			}
+			if text := TextFallback(content); text != "" {
+				result.Content = append(result.Content, &ToolContent{Type: ToolContentText, Text: text})
+			}
+		}
+	}
+	return &result, nil
+}
+
+func isTextContent(c *ToolContent) bool {
+	return c.Type == ToolContentText
+}
+
+func (b *ToolResultBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
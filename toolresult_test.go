@@ -0,0 +1,91 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolResultBuilder(t *testing.T) {
+	result, err := NewToolResult().
+		UI("ui://dash", &HTMLContent{HTML: "<h1>Hi</h1>"}).
+		Resource(&UIResourceContents{URI: "ui://logo", MIMEType: "image/png", Blob: []byte{1, 2}}).
+		Text("Hi").
+		Structured(map[string]any{"count": 1}).
+		Meta("trace", "abc").
+		Build()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"content": [
+			{"type": "resource", "resource": {"uri": "ui://dash", "mimeType": "text/html", "text": "<h1>Hi</h1>"}},
+			{"type": "resource", "resource": {"uri": "ui://logo", "mimeType": "image/png", "blob": "AQI="}},
+			{"type": "text", "text": "Hi"}
+		],
+		"structuredContent": {"count": 1},
+		"_meta": {"trace": "abc"}
+	}`, string(data))
+
+	resources := result.UIResources()
+	require.Len(t, resources, 2)
+	assert.Equal(t, "ui://dash", resources[0].URI)
+	assert.Equal(t, "ui://logo", resources[1].URI)
+}
+
+func TestToolResultBuilder_Empty(t *testing.T) {
+	result, err := NewToolResult().Build()
+	require.NoError(t, err)
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content": []}`, string(data))
+}
+
+func TestToolResultBuilder_Error(t *testing.T) {
+	result, err := NewToolResult().Error(errors.New("service unavailable")).Build()
+	require.NoError(t, err)
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content": [{"type": "text", "text": "service unavailable"}], "isError": true}`, string(data))
+}
+
+func TestToolResultBuilder_InvalidResource(t *testing.T) {
+	_, err := NewToolResult().UI("", &HTMLContent{HTML: "x"}).Text("ignored").Build()
+	assert.ErrorContains(t, err, "URI is required")
+
+	_, err = NewToolResult().UI("ui://a", nil).Build()
+	assert.ErrorContains(t, err, `UI resource "ui://a"`)
+
+	_, err = NewToolResult().Resource(nil).Build()
+	assert.Error(t, err)
+}
+
+func TestToolResultBuilder_WithTextFallback(t *testing.T) {
+	result, err := NewToolResult().
+		UI("ui://dash", &HTMLContent{HTML: "<h1>Status</h1><p>All good</p>"}).
+		UI("ui://ext", &URLContent{URL: "https://example.com/app"}).
+		WithTextFallback().
+		Build()
+	require.NoError(t, err)
+	require.Len(t, result.Content, 4)
+	assert.Equal(t, &ToolContent{Type: ToolContentText, Text: "# Status\n\nAll good"}, result.Content[2])
+	assert.Equal(t, &ToolContent{Type: ToolContentText, Text: "https://example.com/app"}, result.Content[3])
+
+	// Explicit text replaces the automatic fallback.
+	result, err = NewToolResult().
+		UI("ui://dash", &HTMLContent{HTML: "<p>All good</p>"}).
+		Text("Everything is fine.").
+		WithTextFallback().
+		Build()
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "Everything is fine.", result.Content[1].Text)
+}
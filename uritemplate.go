@@ -0,0 +1,582 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// URITemplate is a parsed RFC 6570 URI template. All four levels are
+// supported: simple and reserved expansion, the fragment, label, path,
+// path-style parameter, query and query continuation operators, prefix
+// modifiers (":3") and explode modifiers ("*").
+//
+// Example:
+//
+//	tmpl, err := mcpui.ParseURITemplate("ui://dashboard/{id}{?tab,range}")
+//	uri, err := tmpl.Expand(map[string]any{"id": "42", "tab": "logs"})
+//	// uri == "ui://dashboard/42?tab=logs"
+//	vars, ok := tmpl.Match("ui://dashboard/42?tab=logs")
+//	// vars == map[string]string{"id": "42", "tab": "logs"}, ok == true
+type URITemplate struct {
+	raw   string
+	parts []templatePart
+
+	matcher *regexp.Regexp
+	groups  []templateGroup
+}
+
+// templatePart is a literal or an expression.
+type templatePart struct {
+	literal string
+	expr    *templateExpr
+}
+
+type templateExpr struct {
+	op   *templateOp
+	vars []templateVar
+}
+
+type templateVar struct {
+	name    string
+	prefix  int // 0 means no prefix modifier
+	explode bool
+}
+
+// templateOp holds the expansion rules of an operator (RFC 6570 appendix A).
+type templateOp struct {
+	first         string
+	sep           string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var templateOps = map[byte]*templateOp{
+	0:   {first: "", sep: ","},
+	'+': {first: "", sep: ",", allowReserved: true},
+	'#': {first: "#", sep: ",", allowReserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+// ParseURITemplate parses an RFC 6570 URI template.
+func ParseURITemplate(s string) (*URITemplate, error) {
+	t := &URITemplate{raw: s}
+	rest := s
+	for rest != "" {
+		open := strings.IndexAny(rest, "{}")
+		if open == -1 {
+			t.parts = append(t.parts, templatePart{literal: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("URI template %q: unmatched '}'", s)
+		}
+		if open > 0 {
+			t.parts = append(t.parts, templatePart{literal: rest[:open]})
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end == -1 {
+			return nil, fmt.Errorf("URI template %q: unclosed expression", s)
+		}
+		expr, err := parseTemplateExpr(rest[open+1 : open+end])
+		if err != nil {
+			return nil, fmt.Errorf("URI template %q: %w", s, err)
+		}
+		t.parts = append(t.parts, templatePart{expr: expr})
+		rest = rest[open+end+1:]
+	}
+	for _, p := range t.parts {
+		if p.expr == nil && !validTemplateLiteral(p.literal) {
+			return nil, fmt.Errorf("URI template %q: invalid literal %q", s, p.literal)
+		}
+	}
+	if err := t.compileMatcher(); err != nil {
+		return nil, fmt.Errorf("URI template %q: %w", s, err)
+	}
+	return t, nil
+}
+
+func parseTemplateExpr(s string) (*templateExpr, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	var op byte
+	switch s[0] {
+	case '+', '#', '.', '/', ';', '?', '&':
+		op, s = s[0], s[1:]
+	case '=', ',', '!', '@', '|':
+		return nil, fmt.Errorf("reserved operator %q", s[0])
+	}
+	expr := &templateExpr{op: templateOps[op]}
+	for _, spec := range strings.Split(s, ",") {
+		v := templateVar{name: spec}
+		if name, ok := strings.CutSuffix(spec, "*"); ok {
+			v.name, v.explode = name, true
+		} else if name, prefix, ok := strings.Cut(spec, ":"); ok {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n < 1 || n > 9999 || prefix[0] == '0' {
+				return nil, fmt.Errorf("invalid prefix modifier %q", spec)
+			}
+			v.name, v.prefix = name, n
+		}
+		if !validVarName(v.name) {
+			return nil, fmt.Errorf("invalid variable name %q", v.name)
+		}
+		expr.vars = append(expr.vars, v)
+	}
+	return expr, nil
+}
+
+// validVarName checks the varname production: varchars separated by single dots.
+func validVarName(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isASCIILetter(c), c >= '0' && c <= '9', c == '_':
+		case c == '.':
+			if s[i-1] == '.' {
+				return false
+			}
+		case c == '%':
+			if i+2 >= len(s) || !isHexDigit(s[i+1]) || !isHexDigit(s[i+2]) {
+				return false
+			}
+			i += 2
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validTemplateLiteral rejects characters that may not appear outside
+// expressions: controls, space and " ' < > \ ^ ` { | }.
+func validTemplateLiteral(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c == 0x7f || strings.IndexByte("\"'<>\\^`{|}", c) != -1 {
+			return false
+		}
+		if c == '%' && (i+2 >= len(s) || !isHexDigit(s[i+1]) || !isHexDigit(s[i+2])) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// String returns the template source.
+func (t *URITemplate) String() string { return t.raw }
+
+// Variables returns the variable names in the order they first appear.
+func (t *URITemplate) Variables() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, p := range t.parts {
+		if p.expr == nil {
+			continue
+		}
+		for _, v := range p.expr.vars {
+			if !seen[v.name] {
+				seen[v.name] = true
+				names = append(names, v.name)
+			}
+		}
+	}
+	return names
+}
+
+// Expand substitutes vars into the template. Values may be strings,
+// numbers, booleans, []string, []any, map[string]string or map[string]any;
+// missing, nil and empty list or map values are undefined and omitted.
+// Map keys are expanded in sorted order.
+func (t *URITemplate) Expand(vars map[string]any) (string, error) {
+	var b strings.Builder
+	for _, p := range t.parts {
+		if p.expr == nil {
+			b.WriteString(p.literal)
+			continue
+		}
+		if err := p.expr.expand(&b, vars); err != nil {
+			return "", fmt.Errorf("expand %q: %w", t.raw, err)
+		}
+	}
+	return b.String(), nil
+}
+
+func (e *templateExpr) expand(b *strings.Builder, vars map[string]any) error {
+	op := e.op
+	first := true
+	for _, v := range e.vars {
+		val, err := templateValue(vars[v.name])
+		if err != nil {
+			return fmt.Errorf("variable %q: %w", v.name, err)
+		}
+		if val == nil {
+			continue
+		}
+		if first {
+			b.WriteString(op.first)
+			first = false
+		} else {
+			b.WriteString(op.sep)
+		}
+		enc := func(s string) string { return encodeTemplateValue(s, op.allowReserved) }
+
+		switch val := val.(type) {
+		case string:
+			if op.named {
+				b.WriteString(v.name)
+				if val == "" {
+					b.WriteString(op.ifEmpty)
+					continue
+				}
+				b.WriteByte('=')
+			}
+			if v.prefix > 0 && utf8.RuneCountInString(val) > v.prefix {
+				val = string([]rune(val)[:v.prefix])
+			}
+			b.WriteString(enc(val))
+		case []string:
+			if v.prefix > 0 {
+				return fmt.Errorf("variable %q: prefix modifier on a list", v.name)
+			}
+			if !v.explode {
+				if op.named {
+					b.WriteString(v.name + "=")
+				}
+				for i, item := range val {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					b.WriteString(enc(item))
+				}
+				continue
+			}
+			for i, item := range val {
+				if i > 0 {
+					b.WriteString(op.sep)
+				}
+				if op.named {
+					b.WriteString(v.name)
+					if item == "" {
+						b.WriteString(op.ifEmpty)
+						continue
+					}
+					b.WriteByte('=')
+				}
+				b.WriteString(enc(item))
+			}
+		case [][2]string:
+			if v.prefix > 0 {
+				return fmt.Errorf("variable %q: prefix modifier on a map", v.name)
+			}
+			if !v.explode {
+				if op.named {
+					b.WriteString(v.name + "=")
+				}
+				for i, kv := range val {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					b.WriteString(enc(kv[0]) + "," + enc(kv[1]))
+				}
+				continue
+			}
+			for i, kv := range val {
+				if i > 0 {
+					b.WriteString(op.sep)
+				}
+				b.WriteString(enc(kv[0]))
+				if op.named && kv[1] == "" {
+					b.WriteString(op.ifEmpty)
+					continue
+				}
+				b.WriteString("=" + enc(kv[1]))
+			}
+		}
+	}
+	return nil
+}
+
+// templateValue normalizes a variable value to a string, a []string or a
+// sorted [][2]string of map entries. It returns nil for undefined values.
+func templateValue(v any) (any, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
+	case []string:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		return v, nil
+	case []any:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := templateValue(item)
+			str, ok := s.(string)
+			if err != nil || !ok {
+				return nil, fmt.Errorf("unsupported list item %T", item)
+			}
+			items[i] = str
+		}
+		return items, nil
+	case map[string]string:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		pairs := make([][2]string, 0, len(v))
+		for _, k := range sortedKeys(v) {
+			pairs = append(pairs, [2]string{k, v[k]})
+		}
+		return pairs, nil
+	case map[string]any:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		pairs := make([][2]string, 0, len(v))
+		for _, k := range sortedKeys(v) {
+			s, err := templateValue(v[k])
+			str, ok := s.(string)
+			if err != nil || !ok {
+				return nil, fmt.Errorf("unsupported map value %T", v[k])
+			}
+			pairs = append(pairs, [2]string{k, str})
+		}
+		return pairs, nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+const reservedChars = ":/?#[]@!$&'()*+,;="
+
+// encodeTemplateValue percent-encodes everything except unreserved
+// characters and, if allowReserved, reserved characters and existing
+// percent-encoded triplets.
+func encodeTemplateValue(s string, allowReserved bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isASCIILetter(c), c >= '0' && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case allowReserved && strings.IndexByte(reservedChars, c) != -1:
+			b.WriteByte(c)
+		case allowReserved && c == '%' && i+2 < len(s) && isHexDigit(s[i+1]) && isHexDigit(s[i+2]):
+			b.WriteString(s[i : i+3])
+			i += 2
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// templateGroup maps a regexp capture group of the matcher to the
+// variables it yields.
+type templateGroup struct {
+	expr *templateExpr
+	v    templateVar // for positional operators
+}
+
+// valueClass returns a regexp matching one character of an expanded value:
+// an unreserved character, a reserved character if allowReserved, or a
+// percent-encoded triplet. Commas and the characters in exclude are left out
+// so that a value does not swallow a separator or the operator of an adjacent
+// expression.
+func valueClass(allowReserved bool, exclude string) string {
+	punct := "-._~"
+	if allowReserved {
+		punct += reservedChars
+	}
+	var b strings.Builder
+	b.WriteString(`(?:[A-Za-z0-9`)
+	for i := 0; i < len(punct); i++ {
+		if c := punct[i]; c != ',' && strings.IndexByte(exclude, c) == -1 {
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		}
+	}
+	b.WriteString(`]|%[0-9A-Fa-f]{2})`)
+	return b.String()
+}
+
+// compileMatcher builds the regular expression used by Match.
+func (t *URITemplate) compileMatcher() error {
+	var re strings.Builder
+	re.WriteString("^")
+	for i, p := range t.parts {
+		if p.expr == nil {
+			re.WriteString(regexp.QuoteMeta(p.literal))
+			continue
+		}
+		op := p.expr.op
+		if op.named {
+			// Parameters are parsed after matching, so they may appear in
+			// any order.
+			if op.first == ";" {
+				v := valueClass(false, "")
+				re.WriteString(`((?:;` + v + `*(?:=(?:` + v + `|,)*)?)*)`)
+			} else {
+				re.WriteString(`(?:` + regexp.QuoteMeta(op.first) + `([^#]*))?`)
+			}
+			t.groups = append(t.groups, templateGroup{expr: p.expr})
+			continue
+		}
+		exclude := op.sep
+		if i+1 < len(t.parts) && t.parts[i+1].expr != nil {
+			exclude += t.parts[i+1].expr.op.first
+		}
+		value := valueClass(op.allowReserved, exclude)
+		// Lists are joined with "," or, when exploded, the separator.
+		list := `(?:` + value + `|,)*`
+		if op.sep != "," {
+			list = `(?:` + value + `|,|` + regexp.QuoteMeta(op.sep) + `)*`
+		}
+		re.WriteString(`(?:` + regexp.QuoteMeta(op.first))
+		for j, v := range p.expr.vars {
+			if j > 0 {
+				re.WriteString(`(?:` + regexp.QuoteMeta(op.sep))
+			}
+			if v.explode || len(p.expr.vars) == 1 {
+				re.WriteString(`(` + list + `)`)
+			} else {
+				re.WriteString(`(` + value + `*)`)
+			}
+			t.groups = append(t.groups, templateGroup{expr: p.expr, v: v})
+		}
+		re.WriteString(strings.Repeat(`)?`, len(p.expr.vars)))
+	}
+	re.WriteString("$")
+	m, err := regexp.Compile(re.String())
+	if err != nil {
+		return err
+	}
+	t.matcher = m
+	return nil
+}
+
+// Match reports whether uri could have been produced by expanding the
+// template and, if so, returns the variable values. Values are
+// percent-decoded. List values and exploded variables are returned joined
+// with ","; variables absent from uri are omitted.
+//
+// Matching is the inverse of [URITemplate.Expand] for the common cases of
+// path segments and query parameters; where a template is ambiguous (two
+// adjacent variables without a separator), the leftmost variable is greedy.
+func (t *URITemplate) Match(uri string) (map[string]string, bool) {
+	m := t.matcher.FindStringSubmatchIndex(uri)
+	if m == nil {
+		return nil, false
+	}
+	vars := make(map[string]string)
+	for i, g := range t.groups {
+		start, end := m[2*i+2], m[2*i+3]
+		if start == -1 {
+			continue
+		}
+		raw := uri[start:end]
+		if g.expr.op.named {
+			if !t.matchParams(g.expr, raw, vars) {
+				return nil, false
+			}
+			continue
+		}
+		if g.v.explode && g.expr.op.sep != "," {
+			raw = strings.ReplaceAll(raw, g.expr.op.sep, ",")
+		}
+		val, err := url.PathUnescape(raw)
+		if err != nil {
+			return nil, false
+		}
+		if !setMatchedVar(vars, g.v.name, val) {
+			return nil, false
+		}
+	}
+	return vars, true
+}
+
+// matchParams parses the "name=value" parameters of a ;, ? or & expression.
+// A query expression consumes the rest of the query, so it also recognizes
+// the variables of the template's other query expressions.
+func (t *URITemplate) matchParams(expr *templateExpr, raw string, vars map[string]string) bool {
+	sep := expr.op.sep
+	raw = strings.TrimPrefix(raw, sep)
+	if raw == "" {
+		return true
+	}
+	known := make(map[string]templateVar)
+	for _, p := range t.parts {
+		if p.expr == expr || (p.expr != nil && expr.op.sep == "&" && p.expr.op.sep == "&") {
+			for _, v := range p.expr.vars {
+				known[v.name] = v
+			}
+		}
+	}
+	explodedValues := make(map[string][]string)
+	for _, param := range strings.Split(raw, sep) {
+		name, val, _ := strings.Cut(param, "=")
+		name, err := url.QueryUnescape(name)
+		if err != nil {
+			return false
+		}
+		val, err = url.PathUnescape(val)
+		if err != nil {
+			return false
+		}
+		v, ok := known[name]
+		if !ok {
+			continue // extra parameters are ignored
+		}
+		if v.explode {
+			explodedValues[name] = append(explodedValues[name], val)
+			continue
+		}
+		if !setMatchedVar(vars, name, val) {
+			return false
+		}
+	}
+	for name, vals := range explodedValues {
+		if !setMatchedVar(vars, name, strings.Join(vals, ",")) {
+			return false
+		}
+	}
+	return true
+}
+
+// setMatchedVar records a matched value, rejecting conflicting values for a
+// variable that appears more than once in the template.
+func setMatchedVar(vars map[string]string, name, val string) bool {
+	if old, ok := vars[name]; ok && old != val {
+		return false
+	}
+	vars[name] = val
+	return true
+}
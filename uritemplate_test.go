@@ -0,0 +1,216 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcVars are the example variables of RFC 6570 section 3.2.
+var rfcVars = map[string]any{
+	"count":      []string{"one", "two", "three"},
+	"dom":        []string{"example", "com"},
+	"dub":        "me/too",
+	"hello":      "Hello World!",
+	"half":       "50%",
+	"var":        "value",
+	"who":        "fred",
+	"base":       "http://example.com/home/",
+	"path":       "/foo/bar",
+	"list":       []string{"red", "green", "blue"},
+	"keys":       map[string]string{"semi": ";", "dot": ".", "comma": ","},
+	"v":          "6",
+	"x":          "1024",
+	"y":          "768",
+	"empty":      "",
+	"empty_keys": map[string]string{},
+	"undef":      nil,
+}
+
+func TestURITemplate_Expand(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		// Level 1
+		{"{var}", "value"},
+		{"{hello}", "Hello%20World%21"},
+		{"{half}", "50%25"},
+		{"O{empty}X", "OX"},
+		{"O{undef}X", "OX"},
+		{"{x,y}", "1024,768"},
+		{"{x,hello,y}", "1024,Hello%20World%21,768"},
+		{"?{x,empty}", "?1024,"},
+		{"?{x,undef}", "?1024"},
+		{"{var:3}", "val"},
+		{"{var:30}", "value"},
+		{"{list}", "red,green,blue"},
+		{"{list*}", "red,green,blue"},
+		{"{keys}", "comma,%2C,dot,.,semi,%3B"},
+		{"{keys*}", "comma=%2C,dot=.,semi=%3B"},
+		// Level 2
+		{"{+var}", "value"},
+		{"{+hello}", "Hello%20World!"},
+		{"{+half}", "50%25"},
+		{"{base}index", "http%3A%2F%2Fexample.com%2Fhome%2Findex"},
+		{"{+base}index", "http://example.com/home/index"},
+		{"{+path}/here", "/foo/bar/here"},
+		{"here?ref={+path}", "here?ref=/foo/bar"},
+		{"{+path:6}/here", "/foo/b/here"},
+		{"{+keys*}", "comma=,,dot=.,semi=;"},
+		{"{#var}", "#value"},
+		{"{#hello}", "#Hello%20World!"},
+		{"{#path:6}/here", "#/foo/b/here"},
+		{"{#list*}", "#red,green,blue"},
+		{"{#keys}", "#comma,,,dot,.,semi,;"},
+		// Level 3
+		{"X{.var}", "X.value"},
+		{"X{.x,y}", "X.1024.768"},
+		{"X{.list}", "X.red,green,blue"},
+		{"X{.list*}", "X.red.green.blue"},
+		{"X{.keys*}", "X.comma=%2C.dot=..semi=%3B"},
+		{"X{.empty_keys}", "X"},
+		{"{/who,who}", "/fred/fred"},
+		{"{/var,x}/here", "/value/1024/here"},
+		{"{/var:1,var}", "/v/value"},
+		{"{/list*}", "/red/green/blue"},
+		{"{/list*,path:4}", "/red/green/blue/%2Ffoo"},
+		{"{/keys*}", "/comma=%2C/dot=./semi=%3B"},
+		{"{;who}", ";who=fred"},
+		{"{;half}", ";half=50%25"},
+		{"{;empty}", ";empty"},
+		{"{;v,empty,who}", ";v=6;empty;who=fred"},
+		{"{;x,y,undef}", ";x=1024;y=768"},
+		{"{;hello:5}", ";hello=Hello"},
+		{"{;list}", ";list=red,green,blue"},
+		{"{;list*}", ";list=red;list=green;list=blue"},
+		{"{;keys*}", ";comma=%2C;dot=.;semi=%3B"},
+		{"{?who}", "?who=fred"},
+		{"{?x,y,empty}", "?x=1024&y=768&empty="},
+		{"{?var:3}", "?var=val"},
+		{"{?list}", "?list=red,green,blue"},
+		{"{?list*}", "?list=red&list=green&list=blue"},
+		{"{?keys*}", "?comma=%2C&dot=.&semi=%3B"},
+		{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+		{"{&x,y,empty}", "&x=1024&y=768&empty="},
+		{"{&list*}", "&list=red&list=green&list=blue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			tmpl, err := ParseURITemplate(tt.tmpl)
+			require.NoError(t, err)
+			got, err := tmpl.Expand(rfcVars)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestURITemplate_ExpandValues(t *testing.T) {
+	tmpl, err := ParseURITemplate("ui://items/{id}{?page,tags}")
+	require.NoError(t, err)
+	got, err := tmpl.Expand(map[string]any{"id": 42, "page": true, "tags": []any{"a", 1}})
+	require.NoError(t, err)
+	assert.Equal(t, "ui://items/42?page=true&tags=a,1", got)
+
+	_, err = tmpl.Expand(map[string]any{"id": struct{}{}})
+	assert.Error(t, err)
+
+	tmpl, err = ParseURITemplate("{list:2}")
+	require.NoError(t, err)
+	_, err = tmpl.Expand(map[string]any{"list": []string{"a"}})
+	assert.Error(t, err)
+}
+
+func TestURITemplate_Match(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		uri  string
+		want map[string]string
+	}{
+		{"ui://dashboard/{id}", "ui://dashboard/42", map[string]string{"id": "42"}},
+		{"ui://dashboard/{id}", "ui://dashboard/a%20b", map[string]string{"id": "a b"}},
+		{"ui://{org}/{repo}/view", "ui://acme/web/view", map[string]string{"org": "acme", "repo": "web"}},
+		{"ui://dashboard/{id}{?tab,range}", "ui://dashboard/7?range=1h&tab=logs", map[string]string{"id": "7", "tab": "logs", "range": "1h"}},
+		{"ui://dashboard/{id}{?tab,range}", "ui://dashboard/7", map[string]string{"id": "7"}},
+		{"ui://files/{+path}", "ui://files/docs/a/b.md", map[string]string{"path": "docs/a/b.md"}},
+		{"ui://files{/segments*}", "ui://files/a/b/c", map[string]string{"segments": "a,b,c"}},
+		{"ui://doc/{name}{.ext}", "ui://doc/readme.md", map[string]string{"name": "readme", "ext": "md"}},
+		{"ui://m{;x,y}", "ui://m;y=768;x=1024", map[string]string{"x": "1024", "y": "768"}},
+		{"ui://page{#section}", "ui://page#intro", map[string]string{"section": "intro"}},
+		{"ui://s?fixed=yes{&q}", "ui://s?fixed=yes&q=go", map[string]string{"q": "go"}},
+		{"ui://s{?q}{&page}", "ui://s?q=go&page=2", map[string]string{"q": "go", "page": "2"}},
+		{"ui://s{?tag*}", "ui://s?tag=a&tag=b", map[string]string{"tag": "a,b"}},
+		{"ui://{id}/copy/{id}", "ui://1/copy/1", map[string]string{"id": "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tmpl+" "+tt.uri, func(t *testing.T) {
+			tmpl, err := ParseURITemplate(tt.tmpl)
+			require.NoError(t, err)
+			got, ok := tmpl.Match(tt.uri)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestURITemplate_NoMatch(t *testing.T) {
+	tests := []struct{ tmpl, uri string }{
+		{"ui://dashboard/{id}", "ui://dashboard/42/extra"},
+		{"ui://dashboard/{id}", "ui://other/42"},
+		{"ui://{id}/copy/{id}", "ui://1/copy/2"},
+		{"ui://dashboard/{id}", "ui://dashboard/%zz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			tmpl, err := ParseURITemplate(tt.tmpl)
+			require.NoError(t, err)
+			_, ok := tmpl.Match(tt.uri)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestURITemplate_RoundTrip(t *testing.T) {
+	tmpl, err := ParseURITemplate("ui://reports/{team}/{id}{?from,to}")
+	require.NoError(t, err)
+	vars := map[string]any{"team": "data science", "id": "q3/2025", "from": "2025-07-01"}
+	uri, err := tmpl.Expand(vars)
+	require.NoError(t, err)
+	assert.Equal(t, "ui://reports/data%20science/q3%2F2025?from=2025-07-01", uri)
+	got, ok := tmpl.Match(uri)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"team": "data science", "id": "q3/2025", "from": "2025-07-01"}, got)
+	assert.Equal(t, []string{"team", "id", "from", "to"}, tmpl.Variables())
+	assert.Equal(t, "ui://reports/{team}/{id}{?from,to}", tmpl.String())
+}
+
+func TestParseURITemplate_Errors(t *testing.T) {
+	for _, tmpl := range []string{
+		"ui://x/{id",
+		"ui://x/id}",
+		"ui://x/{}",
+		"ui://x/{=id}",
+		"ui://x/{|id}",
+		"ui://x/{id:0}",
+		"ui://x/{id:10000}",
+		"ui://x/{id:abc}",
+		"ui://x/{i-d}",
+		"ui://x/{a..b}",
+		"ui://x/{.}",
+		"ui://x/{a,}",
+		"ui://x y/{id}",
+		"ui://x/{id}<",
+		"ui://x/%zz",
+	} {
+		t.Run(tmpl, func(t *testing.T) {
+			_, err := ParseURITemplate(tmpl)
+			assert.Error(t, err)
+		})
+	}
+}
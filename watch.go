@@ -0,0 +1,345 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is the default polling interval of [ResourceWatcher].
+const DefaultWatchInterval = 500 * time.Millisecond
+
+// ReloadMessageType is the postMessage type that tells HTML served with a
+// reload hook that its resource changed. See [ResourceWatcher.InjectReload].
+const ReloadMessageType = "ui-reload"
+
+// ReloadRequestMessageType is the postMessage type the reload hook sends to
+// its parent window, with payload {uri}, when
+// [ResourceWatcher.RequestHostReload] is set. It is a custom extension, not
+// part of the MCP-UI protocol: only hosts that handle it by reading the
+// resource again and re-rendering it should enable it.
+const ReloadRequestMessageType = "ui-request-reload"
+
+// ResourceEventKind describes how a resource changed.
+type ResourceEventKind string
+
+const (
+	// ResourceCreated indicates a new resource appeared.
+	ResourceCreated ResourceEventKind = "created"
+	// ResourceUpdated indicates a resource or its metadata changed.
+	ResourceUpdated ResourceEventKind = "updated"
+	// ResourceDeleted indicates a resource was removed.
+	ResourceDeleted ResourceEventKind = "deleted"
+)
+
+// ResourceEvent reports a change to a file-backed resource.
+type ResourceEvent struct {
+	// URI is the URI of the changed resource.
+	URI string `json:"uri"`
+	// Kind describes the change.
+	Kind ResourceEventKind `json:"kind"`
+}
+
+// ResourceListener receives resource change events.
+type ResourceListener func(ResourceEvent)
+
+// ResourceWatcher adds change detection and caching to an [FSProvider] for
+// development. It polls the file system, drops cached contents of changed
+// files, and reports changes to registered listeners so the server can send
+// resource-updated notifications.
+//
+// Polling only uses the standard library and works with any fs.FS that
+// reports modification times, such as os.DirFS. An embed.FS never changes.
+//
+// Example:
+//
+//	provider, _ := mcpui.NewFSProvider(os.DirFS("ui"), "ui://app/")
+//	watcher := mcpui.NewResourceWatcher(provider)
+//	watcher.InjectReload = true
+//	watcher.AddListener(func(e mcpui.ResourceEvent) {
+//		log.Printf("%s %s", e.Kind, e.URI)
+//	})
+//	go watcher.Run(ctx)
+type ResourceWatcher struct {
+	// Interval is the polling interval. Zero means DefaultWatchInterval.
+	Interval time.Duration
+	// InjectReload adds a script to served HTML that reloads the page when
+	// it receives a postMessage of type ReloadMessageType. If
+	// ReloadEventsURL is set, the script also reloads when that
+	// server-sent events endpoint reports an update for the resource.
+	//
+	// The script calls location.reload, which only picks up changes in
+	// iframes loaded from a URL: a srcdoc document replays its old HTML.
+	// Hosts that render srcdoc can opt in to RequestHostReload.
+	InjectReload bool
+	// RequestHostReload makes the reload hook post a
+	// ReloadRequestMessageType message to its parent window instead of
+	// reloading itself. Enable it only for hosts that implement this
+	// extension.
+	RequestHostReload bool
+	// ReloadEventsURL is an optional server-sent events endpoint used by
+	// the reload hook. Events named "resource-updated" whose data is a
+	// resources/updated notification for the resource trigger a reload
+	// request.
+	ReloadEventsURL string
+	// OnError, if set, receives errors from background polling.
+	OnError func(error)
+
+	provider *FSProvider
+
+	mu        sync.Mutex
+	snapshot  map[string]fileState // by URI; nil until the first poll
+	cache     map[string]UIContent
+	gen       uint64 // incremented when Poll invalidates the cache
+	listeners map[int]ResourceListener
+	nextID    int
+}
+
+// fileState identifies a version of a resource file and its sidecar.
+type fileState struct {
+	size, metaSize int64
+	mod, metaMod   time.Time
+}
+
+// NewResourceWatcher creates a watcher for the provider's file system.
+func NewResourceWatcher(provider *FSProvider) *ResourceWatcher {
+	return &ResourceWatcher{
+		provider:  provider,
+		cache:     make(map[string]UIContent),
+		listeners: make(map[int]ResourceListener),
+	}
+}
+
+// Provider returns the watched provider.
+func (w *ResourceWatcher) Provider() *FSProvider { return w.provider }
+
+// AddListener registers a listener for change events and returns a function
+// that removes it. Listeners are called synchronously from [ResourceWatcher.Poll].
+func (w *ResourceWatcher) AddListener(l ResourceListener) (remove func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.listeners[id] = l
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.listeners, id)
+	}
+}
+
+// Run polls until ctx is done and returns ctx.Err(). Poll errors are passed
+// to OnError and do not stop the watcher.
+func (w *ResourceWatcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.Poll(); err != nil && w.OnError != nil {
+			w.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll scans the file system once, invalidates cached contents of changed
+// resources, notifies listeners and returns the events. The first call only
+// records the current state and reports no events.
+func (w *ResourceWatcher) Poll() ([]ResourceEvent, error) {
+	current, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	previous := w.snapshot
+	w.snapshot = current
+	if previous == nil {
+		w.mu.Unlock()
+		return nil, nil
+	}
+	var events []ResourceEvent
+	for _, uri := range sortedKeys(current) {
+		old, ok := previous[uri]
+		switch {
+		case !ok:
+			events = append(events, ResourceEvent{URI: uri, Kind: ResourceCreated})
+		case old != current[uri]:
+			events = append(events, ResourceEvent{URI: uri, Kind: ResourceUpdated})
+		}
+	}
+	for _, uri := range sortedKeys(previous) {
+		if _, ok := current[uri]; !ok {
+			events = append(events, ResourceEvent{URI: uri, Kind: ResourceDeleted})
+		}
+	}
+	for _, e := range events {
+		delete(w.cache, e.URI)
+	}
+	if len(events) > 0 {
+		w.gen++
+	}
+	listeners := make([]ResourceListener, 0, len(w.listeners))
+	for _, id := range sortedKeys(w.listeners) {
+		listeners = append(listeners, w.listeners[id])
+	}
+	w.mu.Unlock()
+
+	for _, e := range events {
+		for _, l := range listeners {
+			l(e)
+		}
+	}
+	return events, nil
+}
+
+// scan records the state of every resource file and its sidecar.
+func (w *ResourceWatcher) scan() (map[string]fileState, error) {
+	p := w.provider
+	entries, err := p.entries()
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]fileState, len(entries))
+	for _, e := range entries {
+		info, err := fs.Stat(p.fsys, e.file)
+		if err != nil {
+			return nil, err
+		}
+		st := fileState{size: info.Size(), mod: info.ModTime()}
+		if meta, err := fs.Stat(p.fsys, sidecarPath(e)); err == nil {
+			st.metaSize, st.metaMod = meta.Size(), meta.ModTime()
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		states[p.base+e.name] = st
+	}
+	return states, nil
+}
+
+// Content returns a copy of the content of the resource at uri, from the
+// cache when possible. HTML content carries the reload hook if InjectReload
+// is set. Contents read while Poll invalidates the cache are not cached.
+func (w *ResourceWatcher) Content(uri string) (UIContent, error) {
+	w.mu.Lock()
+	content, ok := w.cache[uri]
+	gen := w.gen
+	w.mu.Unlock()
+	if ok {
+		return copyFSContent(content), nil
+	}
+
+	content, err := w.provider.Content(uri)
+	if err != nil {
+		return nil, err
+	}
+	if html, ok := content.(*HTMLContent); ok && w.InjectReload {
+		html.HTML = injectReloadHook(html.HTML, uri, w.ReloadEventsURL, w.RequestHostReload)
+	}
+
+	w.mu.Lock()
+	if w.gen == gen {
+		w.cache[uri] = content
+	}
+	w.mu.Unlock()
+	return copyFSContent(content), nil
+}
+
+// copyFSContent returns a deep copy of content created by an [FSProvider].
+func copyFSContent(content UIContent) UIContent {
+	switch c := content.(type) {
+	case *HTMLContent:
+		cp := *c
+		cp.MIMEParams = maps.Clone(c.MIMEParams)
+		cp.Annotations = copyAnnotations(c.Annotations)
+		return &cp
+	case *RemoteDOMContent:
+		cp := *c
+		cp.MIMEParams = maps.Clone(c.MIMEParams)
+		cp.Annotations = copyAnnotations(c.Annotations)
+		return &cp
+	case *BlobContent:
+		cp := *c
+		cp.Data = slices.Clone(c.Data)
+		cp.Annotations = copyAnnotations(c.Annotations)
+		return &cp
+	}
+	return content
+}
+
+// List returns all resources of the provider, see [FSProvider.List].
+func (w *ResourceWatcher) List(ctx context.Context, cursor string) (*ListUIResourcesResult, error) {
+	return w.provider.List(ctx, cursor)
+}
+
+// Read returns the contents of the resource at uri, using the cache.
+func (w *ResourceWatcher) Read(ctx context.Context, uri string) (*ReadUIResourceResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content, err := w.Content(uri)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := NewUIResourceContents(uri, content)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadUIResourceResult{Contents: []*UIResourceContents{rc}}, nil
+}
+
+// injectReloadHook adds a development reload script to an HTML document,
+// before </body> if present and at the end otherwise.
+func injectReloadHook(doc, uri, eventsURL string, requestHost bool) string {
+	var b strings.Builder
+	b.WriteString("<script>(function () {\n")
+	b.WriteString("  var uri = " + jsString(uri) + ";\n")
+	b.WriteString("  function reload() {\n")
+	if requestHost {
+		b.WriteString("    window.parent.postMessage({ type: " + jsString(ReloadRequestMessageType) + ", payload: { uri: uri } }, \"*\");\n")
+	} else {
+		b.WriteString("    location.reload();\n")
+	}
+	b.WriteString("  }\n")
+	b.WriteString("  window.addEventListener(\"message\", function (e) {\n")
+	b.WriteString("    if (e.data && e.data.type === " + jsString(ReloadMessageType) + ") { reload(); }\n")
+	b.WriteString("  });\n")
+	if eventsURL != "" {
+		b.WriteString("  var es = new EventSource(" + jsString(eventsURL) + ");\n")
+		b.WriteString("  es.addEventListener(\"resource-updated\", function (e) {\n")
+		b.WriteString("    var msg;\n")
+		b.WriteString("    try { msg = JSON.parse(e.data); } catch (err) { return; }\n")
+		b.WriteString("    if (msg && msg.params && msg.params.uri === uri) { reload(); }\n")
+		b.WriteString("  });\n")
+	}
+	b.WriteString("})();</script>")
+	hook := b.String()
+
+	offset, at := 0, -1
+	for _, tok := range tokenizeHTML(doc) {
+		if tok.Type == htmlEndTag && tok.Data == "body" {
+			at = offset
+		}
+		offset += len(tok.Raw)
+	}
+	if at == -1 {
+		return doc + hook
+	}
+	return doc[:at] + hook + doc[at:]
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 The MCP-UI Go SDK Authors. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mcpui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceWatcher_Poll(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"main.html":  {Data: []byte("<p>v1</p>"), ModTime: t0},
+		"other.html": {Data: []byte("<p>other</p>"), ModTime: t0},
+	}
+	p, err := NewFSProvider(fsys, "ui://app/")
+	require.NoError(t, err)
+	w := NewResourceWatcher(p)
+
+	var got []ResourceEvent
+	remove := w.AddListener(func(e ResourceEvent) { got = append(got, e) })
+
+	events, err := w.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, events, "first poll only records state")
+
+	content, err := w.Content("ui://app/main")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>v1</p>", content.(*HTMLContent).HTML)
+
+	// Served from the cache until the file changes.
+	fsys["main.html"] = &fstest.MapFile{Data: []byte("<p>v2</p>"), ModTime: t0}
+	content, err = w.Content("ui://app/main")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>v1</p>", content.(*HTMLContent).HTML)
+
+	fsys["main.html"].ModTime = t0.Add(time.Second)
+	fsys["other.meta.json"] = &fstest.MapFile{Data: []byte(`{"title":"Other"}`), ModTime: t0}
+	fsys["new.html"] = &fstest.MapFile{Data: []byte("new"), ModTime: t0}
+	events, err = w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []ResourceEvent{
+		{URI: "ui://app/main", Kind: ResourceUpdated},
+		{URI: "ui://app/new", Kind: ResourceCreated},
+		{URI: "ui://app/other", Kind: ResourceUpdated},
+	}, events)
+	assert.Equal(t, events, got)
+
+	result, err := w.Read(context.Background(), "ui://app/main")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>v2</p>", result.Contents[0].Text)
+
+	remove()
+	delete(fsys, "new.html")
+	events, err = w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []ResourceEvent{{URI: "ui://app/new", Kind: ResourceDeleted}}, events)
+	assert.Len(t, got, 3, "removed listener is not called")
+
+	events, err = w.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestResourceWatcher_InjectReload(t *testing.T) {
+	fsys := fstest.MapFS{
+		"page.html": {Data: []byte("<html><body><p>hi</p></body></html>")},
+		"bare.html": {Data: []byte("<p>bare</p>")},
+	}
+	p, err := NewFSProvider(fsys, "ui://app/")
+	require.NoError(t, err)
+	w := NewResourceWatcher(p)
+	w.InjectReload = true
+	w.ReloadEventsURL = "https://dev.example.com/events"
+
+	content, err := w.Content("ui://app/page")
+	require.NoError(t, err)
+	html := content.(*HTMLContent).HTML
+	assert.True(t, strings.HasPrefix(html, "<html><body><p>hi</p><script>"))
+	assert.True(t, strings.HasSuffix(html, "</script></body></html>"))
+	assert.Contains(t, html, `"ui-reload"`)
+	assert.Contains(t, html, "location.reload()")
+	assert.NotContains(t, html, ReloadRequestMessageType, "host reload requests are opt-in")
+	assert.Contains(t, html, `new EventSource("https://dev.example.com/events")`)
+	assert.Contains(t, html, `"ui://app/page"`)
+	assert.Contains(t, html, "msg.params.uri === uri", "URIs are compared exactly")
+	assert.NotContains(t, html, "indexOf")
+
+	content, err = w.Content("ui://app/bare")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content.(*HTMLContent).HTML, "<p>bare</p><script>"))
+
+	w = NewResourceWatcher(p)
+	w.InjectReload = true
+	w.RequestHostReload = true
+	content, err = w.Content("ui://app/page")
+	require.NoError(t, err)
+	html = content.(*HTMLContent).HTML
+	assert.Contains(t, html, `window.parent.postMessage({ type: "ui-request-reload", payload: { uri: uri } }, "*")`)
+	assert.NotContains(t, html, "location.reload")
+}
+
+func TestResourceWatcher_ContentCopies(t *testing.T) {
+	fsys := fstest.MapFS{
+		"page.html":      {Data: []byte("<p>hi</p>")},
+		"page.meta.json": {Data: []byte(`{"annotations":{"audience":["user"]}}`)},
+		"logo.png":       {Data: []byte("PNG")},
+	}
+	p, err := NewFSProvider(fsys, "ui://app/")
+	require.NoError(t, err)
+	w := NewResourceWatcher(p)
+
+	content, err := w.Content("ui://app/page")
+	require.NoError(t, err)
+	html := content.(*HTMLContent)
+	html.HTML = "changed"
+	html.Annotations.Audience[0] = "assistant"
+
+	blob, err := w.Content("ui://app/logo.png")
+	require.NoError(t, err)
+	blob.(*BlobContent).Data[0] = 'X'
+
+	content, err = w.Content("ui://app/page")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>hi</p>", content.(*HTMLContent).HTML)
+	assert.Equal(t, []string{"user"}, content.(*HTMLContent).Annotations.Audience)
+	blob, err = w.Content("ui://app/logo.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("PNG"), blob.(*BlobContent).Data)
+}
+
+// hookFS calls afterRead after every ReadFile.
+type hookFS struct {
+	fstest.MapFS
+	afterRead func(name string)
+}
+
+func (f hookFS) ReadFile(name string) ([]byte, error) {
+	data, err := f.MapFS.ReadFile(name)
+	f.afterRead(name)
+	return data, err
+}
+
+func TestResourceWatcher_ContentRacesPoll(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{"main.html": {Data: []byte("v1"), ModTime: t0}}
+	var w *ResourceWatcher
+	changed := false
+	hooked := hookFS{MapFS: fsys, afterRead: func(name string) {
+		if name != "main.html" || changed {
+			return
+		}
+		// The file changes and is polled after the read returned v1.
+		changed = true
+		fsys["main.html"] = &fstest.MapFile{Data: []byte("v2"), ModTime: t0.Add(time.Second)}
+		_, err := w.Poll()
+		require.NoError(t, err)
+	}}
+	p, err := NewFSProvider(hooked, "ui://app/")
+	require.NoError(t, err)
+	w = NewResourceWatcher(p)
+	_, err = w.Poll()
+	require.NoError(t, err)
+
+	content, err := w.Content("ui://app/main")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", content.(*HTMLContent).HTML)
+
+	content, err = w.Content("ui://app/main")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", content.(*HTMLContent).HTML, "stale read is not cached")
+}
+
+func TestResourceWatcher_Run(t *testing.T) {
+	fsys := fstest.MapFS{"a.html": {Data: []byte("a")}}
+	p, err := NewFSProvider(fsys, "ui://app/")
+	require.NoError(t, err)
+	w := NewResourceWatcher(p)
+	w.Interval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Run(ctx), context.DeadlineExceeded)
+}